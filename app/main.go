@@ -5,16 +5,34 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/abrahammegantoro/to-do-list-be/attachment"
+	"github.com/abrahammegantoro/to-do-list-be/calendar"
+	"github.com/abrahammegantoro/to-do-list-be/comment"
+	"github.com/abrahammegantoro/to-do-list-be/internal/notifier"
+	"github.com/abrahammegantoro/to-do-list-be/internal/pubsub"
 	"github.com/abrahammegantoro/to-do-list-be/internal/repository/psql"
 	"github.com/abrahammegantoro/to-do-list-be/internal/rest"
 	"github.com/abrahammegantoro/to-do-list-be/internal/rest/middlewares"
+	"github.com/abrahammegantoro/to-do-list-be/internal/storage"
+	"github.com/abrahammegantoro/to-do-list-be/internal/worker"
+	"github.com/abrahammegantoro/to-do-list-be/list"
+	"github.com/abrahammegantoro/to-do-list-be/notification"
+	"github.com/abrahammegantoro/to-do-list-be/reminder"
+	"github.com/abrahammegantoro/to-do-list-be/template"
+	"github.com/abrahammegantoro/to-do-list-be/timeentry"
 	"github.com/abrahammegantoro/to-do-list-be/todo"
 	"github.com/abrahammegantoro/to-do-list-be/user"
+	"github.com/abrahammegantoro/to-do-list-be/view"
+	"github.com/abrahammegantoro/to-do-list-be/webhook"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
-	"github.com/labstack/echo/v4/middleware"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 func init() {
@@ -41,15 +59,58 @@ func main() {
 	}
 	defer conn.Close()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	e := echo.New()
 	// e.Use(middlewares.CORS)
 	e.Use(middleware.CORS())
 
 	userRepo := psql.NewUserRepository(conn)
 	todoRepo := psql.NewTodoRepository(conn)
+	todoEventRepo := psql.NewTodoEventRepository(conn)
+	listRepo := psql.NewListRepository(conn)
+	attachmentRepo := psql.NewAttachmentRepository(conn)
+	commentRepo := psql.NewCommentRepository(conn)
+	webhookRepo := psql.NewWebhookRepository(conn)
+	reminderRepo := psql.NewReminderRepository(conn)
+	notificationRepo := psql.NewNotificationRepository(conn)
+	viewRepo := psql.NewViewRepository(conn)
+	timeEntryRepo := psql.NewTimeEntryRepository(conn)
+	templateRepo := psql.NewTemplateRepository(conn)
+	transactor := psql.NewTransactor(conn)
 
+	attachmentDir := os.Getenv("ATTACHMENT_DIR")
+	if attachmentDir == "" {
+		attachmentDir = "uploads"
+	}
+	blobStore, err := storage.NewLocalStore(attachmentDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to prepare attachment storage: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Events only reach clients connected to this instance unless they are
+	// relayed through Postgres.
+	var broker pubsub.Broker = pubsub.NewHub()
+	if os.Getenv("EVENTS_BROKER") == "postgres" {
+		pgBroker := pubsub.NewPostgresBroker(conn)
+		go pgBroker.Listen(ctx)
+		broker = pgBroker
+	}
+
+	notificationService := notification.NewNotificationService(notificationRepo)
 	userService := user.NewUserService(userRepo)
-	todoService := todo.NewTodoService(todoRepo)
+	todoService := todo.NewTodoService(todoRepo, todoEventRepo, listRepo, userRepo, webhookRepo, notificationService, broker, transactor)
+	listService := list.NewListService(listRepo, userRepo, transactor)
+	attachmentService := attachment.NewAttachmentService(attachmentRepo, todoService, blobStore, int64(getEnvInt("ATTACHMENT_MAX_SIZE_MB", 10))<<20)
+	commentService := comment.NewCommentService(commentRepo, userRepo, todoService, notificationService, transactor)
+	reminderService := reminder.NewReminderService(reminderRepo, todoService, notificationService, newChannels(userRepo), transactor)
+	calendarService := calendar.NewCalendarService(userRepo, todoRepo)
+	viewService := view.NewViewService(viewRepo)
+	timeEntryService := timeentry.NewTimeEntryService(timeEntryRepo, todoService, userRepo, transactor)
+	templateService := template.NewTemplateService(templateRepo, todoService, listService, userRepo)
+	webhookService := webhook.NewWebhookService(webhookRepo, transactor, time.Duration(getEnvInt("WEBHOOK_TIMEOUT_SECONDS", 10))*time.Second)
 
 	api := e.Group("/api/v1")
 
@@ -58,7 +119,131 @@ func main() {
 	todoApi := api.Group("/todos")
 	todoApi.Use(middlewares.AuthMiddleware(userRepo))
 
-	rest.NewTodoHandler(todoApi, todoService)
+	rest.NewTodoHandler(todoApi, todoService, viewService)
+	rest.NewAttachmentHandler(todoApi, attachmentService)
+	rest.NewCommentHandler(todoApi, commentService)
+	rest.NewReminderHandler(todoApi, reminderService)
+	rest.NewTimeEntryHandler(todoApi, timeEntryService)
+	rest.NewImportHandler(todoApi, todoService, int64(getEnvInt("IMPORT_MAX_SIZE_MB", 10))<<20)
+
+	listApi := api.Group("/lists")
+	listApi.Use(middlewares.AuthMiddleware(userRepo))
+
+	rest.NewListHandler(listApi, listService)
+
+	webhookApi := api.Group("/webhooks")
+	webhookApi.Use(middlewares.AuthMiddleware(userRepo))
+
+	rest.NewWebhookHandler(webhookApi, webhookService)
+
+	viewApi := api.Group("/views")
+	viewApi.Use(middlewares.AuthMiddleware(userRepo))
+
+	rest.NewViewHandler(viewApi, viewService)
+
+	templateApi := api.Group("/templates")
+	templateApi.Use(middlewares.AuthMiddleware(userRepo))
+
+	rest.NewTemplateHandler(templateApi, templateService)
+
+	meApi := api.Group("/me")
+	meApi.Use(middlewares.AuthMiddleware(userRepo))
+
+	rest.NewSettingsHandler(meApi, userService)
+	rest.NewActivityHandler(meApi, todoService)
+	rest.NewStatsHandler(meApi, todoService)
+	rest.NewTimerHandler(meApi, timeEntryService)
+	rest.NewNotificationHandler(meApi, notificationService)
+	rest.NewCalendarTokenHandler(meApi, calendarService)
+
+	// Calendar clients cannot log in; the feed token in the URL is the
+	// credential.
+	rest.NewCalendarHandler(api.Group("/calendar"), calendarService)
+
+	eventApi := api.Group("/events")
+	eventApi.Use(middlewares.QueryToken, middlewares.AuthMiddleware(userRepo))
+
+	var originPatterns []string
+	if origins := os.Getenv("EVENTS_ALLOWED_ORIGINS"); origins != "" {
+		originPatterns = strings.Split(origins, ",")
+	}
+	rest.NewEventHandler(eventApi, broker, originPatterns)
+
+	trashRetention := time.Duration(getEnvInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour
+	go worker.Run(ctx, "trash-purge", time.Hour, func(ctx context.Context) error {
+		purged, err := todoService.PurgeTrash(ctx, trashRetention)
+		if purged > 0 {
+			logrus.Infof("purged %d todos from trash", purged)
+		}
+		return err
+	})
+
+	go worker.Run(ctx, "auto-archive", time.Hour, func(ctx context.Context) error {
+		archived, err := todoService.AutoArchive(ctx)
+		if archived > 0 {
+			logrus.Infof("auto-archived %d completed todos", archived)
+		}
+		return err
+	})
+
+	go worker.Run(ctx, "attachment-sweep", time.Hour, func(ctx context.Context) error {
+		_, err := attachmentService.PurgeOrphans(ctx)
+		return err
+	})
+
+	go worker.Run(ctx, "overdue-check", time.Minute, func(ctx context.Context) error {
+		_, err := todoService.NotifyOverdue(ctx)
+		return err
+	})
+
+	go worker.Run(ctx, "reminders", time.Minute, func(ctx context.Context) error {
+		_, err := reminderService.Dispatch(ctx)
+		return err
+	})
+
+	go worker.Run(ctx, "reminder-delivery", 10*time.Second, func(ctx context.Context) error {
+		_, err := reminderService.Deliver(ctx)
+		return err
+	})
+
+	go worker.Run(ctx, "webhook-dispatch", 10*time.Second, func(ctx context.Context) error {
+		_, err := webhookService.Dispatch(ctx)
+		return err
+	})
 
 	e.Logger.Fatal(e.Start(":8080"))
 }
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+// newChannels builds the reminder channels listed in NOTIFIERS
+// (comma-separated: log, email, webhook), the log when none is.
+func newChannels(directory notifier.EmailDirectory) map[string]reminder.Notifier {
+	channels := map[string]reminder.Notifier{}
+	for _, name := range strings.Split(os.Getenv("NOTIFIERS"), ",") {
+		name = strings.TrimSpace(name)
+		switch name {
+		case "log":
+			channels[name] = notifier.Log{}
+		case "email":
+			channels[name] = notifier.NewEmail(os.Getenv("SMTP_HOST"), os.Getenv("SMTP_PORT"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), os.Getenv("SMTP_FROM"), directory)
+		case "webhook":
+			channels[name] = notifier.NewWebhook(os.Getenv("NOTIFY_WEBHOOK_URL"), os.Getenv("NOTIFY_WEBHOOK_SECRET"), time.Duration(getEnvInt("WEBHOOK_TIMEOUT_SECONDS", 10))*time.Second)
+		case "":
+		default:
+			logrus.Warnf("unknown notifier %q", name)
+		}
+	}
+	if len(channels) == 0 {
+		channels["log"] = notifier.Log{}
+	}
+
+	return channels
+}
@@ -0,0 +1,241 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/sirupsen/logrus"
+)
+
+type AttachmentRepository interface {
+	GetByTodoID(ctx context.Context, todoID int64) ([]domain.Attachment, error)
+	GetByID(ctx context.Context, id int64) (domain.Attachment, error)
+	GetOrphans(ctx context.Context, limit int64) ([]domain.Attachment, error)
+	Store(ctx context.Context, at *domain.Attachment) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type TodoAuthorizer interface {
+	Authorize(ctx context.Context, userID int64, id int64, required domain.ListRole) (domain.Todo, error)
+}
+
+// BlobStore holds the attachment contents. The local filesystem store is the
+// one shipped; an S3-compatible store only has to implement these methods.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// sniffLength is how much of an upload is read to detect its content type.
+const sniffLength = 3072
+
+// maxFilenameLength is the longest filename the attachments table holds.
+const maxFilenameLength = 255
+
+var allowedContentTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"application/pdf",
+	"text/plain",
+}
+
+type AttachmentService struct {
+	attachmentRepository AttachmentRepository
+	todoAuthorizer       TodoAuthorizer
+	blobStore            BlobStore
+	maxSize              int64
+}
+
+func NewAttachmentService(ar AttachmentRepository, ta TodoAuthorizer, bs BlobStore, maxSize int64) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepository: ar,
+		todoAuthorizer:       ta,
+		blobStore:            bs,
+		maxSize:              maxSize,
+	}
+}
+
+func (a *AttachmentService) MaxSize() int64 {
+	return a.maxSize
+}
+
+func (a *AttachmentService) getAttachment(ctx context.Context, userID int64, todoID int64, id int64, required domain.ListRole) (res domain.Attachment, err error) {
+	if _, err = a.todoAuthorizer.Authorize(ctx, userID, todoID, required); err != nil {
+		return
+	}
+
+	res, err = a.attachmentRepository.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if res.TodoID == nil || *res.TodoID != todoID {
+		return domain.Attachment{}, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (a *AttachmentService) GetByTodoID(ctx context.Context, userID int64, todoID int64) (res []domain.Attachment, err error) {
+	if _, err = a.todoAuthorizer.Authorize(ctx, userID, todoID, domain.RoleViewer); err != nil {
+		return
+	}
+
+	return a.attachmentRepository.GetByTodoID(ctx, todoID)
+}
+
+// Upload stores r as a new attachment of the todo. The content type is
+// sniffed from the data itself rather than trusted from the client, and
+// uploads above the configured size are rejected, as are filenames longer
+// than maxFilenameLength characters.
+func (a *AttachmentService) Upload(ctx context.Context, userID int64, todoID int64, filename string, r io.Reader) (res domain.Attachment, err error) {
+	if utf8.RuneCountInString(filename) > maxFilenameLength {
+		return domain.Attachment{}, domain.ErrBadParamInput
+	}
+	if _, err = a.todoAuthorizer.Authorize(ctx, userID, todoID, domain.RoleEditor); err != nil {
+		return
+	}
+
+	limited := &countingReader{r: io.LimitReader(r, a.maxSize+1)}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(limited, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return
+	}
+	head = head[:n]
+
+	mtype := mimetype.Detect(head)
+	if !isAllowed(mtype) {
+		return domain.Attachment{}, domain.ErrUnsupportedMedia
+	}
+
+	key, err := newStorageKey(todoID)
+	if err != nil {
+		return
+	}
+
+	err = a.blobStore.Put(ctx, key, io.MultiReader(bytes.NewReader(head), limited))
+	if err != nil {
+		return
+	}
+	if limited.n > a.maxSize {
+		a.deleteBlob(ctx, key)
+		return domain.Attachment{}, domain.ErrFileTooLarge
+	}
+
+	res = domain.Attachment{
+		TodoID:      &todoID,
+		UserID:      userID,
+		Filename:    filename,
+		ContentType: mtype.String(),
+		Size:        limited.n,
+		StorageKey:  key,
+		CreatedAt:   time.Now(),
+	}
+	err = a.attachmentRepository.Store(ctx, &res)
+	if err != nil {
+		a.deleteBlob(ctx, key)
+		return domain.Attachment{}, err
+	}
+
+	return
+}
+
+// Download returns the attachment together with its contents. The caller is
+// responsible for closing the reader.
+func (a *AttachmentService) Download(ctx context.Context, userID int64, todoID int64, id int64) (res domain.Attachment, body io.ReadCloser, err error) {
+	res, err = a.getAttachment(ctx, userID, todoID, id, domain.RoleViewer)
+	if err != nil {
+		return
+	}
+
+	body, err = a.blobStore.Get(ctx, res.StorageKey)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+
+	return
+}
+
+func (a *AttachmentService) Delete(ctx context.Context, userID int64, todoID int64, id int64) (err error) {
+	at, err := a.getAttachment(ctx, userID, todoID, id, domain.RoleEditor)
+	if err != nil {
+		return
+	}
+
+	err = a.attachmentRepository.Delete(ctx, at.ID)
+	if err != nil {
+		return
+	}
+
+	a.deleteBlob(ctx, at.StorageKey)
+	return
+}
+
+// PurgeOrphans deletes the blobs and rows of attachments whose todo has been
+// permanently deleted.
+func (a *AttachmentService) PurgeOrphans(ctx context.Context) (res int64, err error) {
+	orphans, err := a.attachmentRepository.GetOrphans(ctx, 100)
+	if err != nil {
+		return
+	}
+
+	for _, at := range orphans {
+		if err = a.blobStore.Delete(ctx, at.StorageKey); err != nil {
+			return
+		}
+		if err = a.attachmentRepository.Delete(ctx, at.ID); err != nil {
+			return
+		}
+		res++
+	}
+
+	return
+}
+
+func (a *AttachmentService) deleteBlob(ctx context.Context, key string) {
+	if err := a.blobStore.Delete(ctx, key); err != nil {
+		logrus.Error(err)
+	}
+}
+
+func isAllowed(mtype *mimetype.MIME) bool {
+	for _, allowed := range allowedContentTypes {
+		if mtype.Is(allowed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newStorageKey(todoID int64) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("todos/%d/%s", todoID, hex.EncodeToString(random)), nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,122 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/abrahammegantoro/to-do-list-be/internal/ical"
+)
+
+// Component is the kind of calendar entry a todo becomes. Many calendar
+// clients only show events, while task apps read VTODOs.
+type Component string
+
+const (
+	ComponentEvent Component = "event"
+	ComponentTodo  Component = "todo"
+)
+
+// priorities maps priority levels onto the 1 (highest) to 9 (lowest) scale
+// of the iCalendar PRIORITY property.
+var priorities = map[domain.PriorityLevel]string{
+	domain.Urgent: "1",
+	domain.High:   "3",
+	domain.Medium: "5",
+	domain.Low:    "7",
+	domain.Lowest: "9",
+}
+
+// WriteICS writes the todos as an iCalendar document. host makes the UIDs
+// unique across installations.
+func WriteICS(w io.Writer, todos []domain.Todo, component Component, host string) error {
+	cal := ical.NewWriter(w)
+
+	cal.Begin("VCALENDAR")
+	cal.Property("VERSION", "2.0")
+	cal.Property("PRODID", "-//to-do-list-be//Todo List//EN")
+	cal.Property("CALSCALE", "GREGORIAN")
+	cal.Property("METHOD", "PUBLISH")
+	cal.Text("X-WR-CALNAME", "Todos")
+	cal.Property("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	cal.Property("X-PUBLISHED-TTL", "PT1H")
+
+	for _, td := range todos {
+		switch component {
+		case ComponentTodo:
+			writeTodo(cal, td, host)
+		default:
+			writeEvent(cal, td, host)
+		}
+	}
+
+	cal.End("VCALENDAR")
+
+	return cal.Flush()
+}
+
+func writeTodo(cal *ical.Writer, td domain.Todo, host string) {
+	cal.Begin("VTODO")
+	writeCommon(cal, td, host)
+	cal.Text("SUMMARY", td.Text)
+	// A recurrence needs a DTSTART to repeat from. DUE has to come after
+	// DTSTART, so a recurring todo carries its date in DTSTART alone.
+	if td.RecurrenceRule != nil {
+		writeDate(cal, "DTSTART", td)
+	} else {
+		writeDate(cal, "DUE", td)
+	}
+	if td.Completed {
+		cal.Property("STATUS", "COMPLETED")
+		cal.Property("PERCENT-COMPLETE", "100")
+		if td.CompletedAt != nil {
+			cal.Time("COMPLETED", *td.CompletedAt)
+		}
+	} else {
+		cal.Property("STATUS", "NEEDS-ACTION")
+	}
+	cal.End("VTODO")
+}
+
+// writeEvent writes the todo as a zero-length event at its due date, or as
+// an all-day event. Events have no completed state, so completed todos are
+// marked in the summary.
+func writeEvent(cal *ical.Writer, td domain.Todo, host string) {
+	cal.Begin("VEVENT")
+	writeCommon(cal, td, host)
+	writeDate(cal, "DTSTART", td)
+	if td.Completed {
+		cal.Text("SUMMARY", "✓ "+td.Text)
+	} else {
+		cal.Text("SUMMARY", td.Text)
+	}
+	cal.Property("TRANSP", "TRANSPARENT")
+	cal.End("VEVENT")
+}
+
+// writeDate writes the date of an all-day todo as a floating calendar day,
+// which clients show on that day in any zone.
+func writeDate(cal *ical.Writer, name string, td domain.Todo) {
+	if td.AllDay {
+		cal.Date(name, *td.Date)
+	} else {
+		cal.Time(name, *td.Date)
+	}
+}
+
+func writeCommon(cal *ical.Writer, td domain.Todo, host string) {
+	cal.Property("UID", fmt.Sprintf("todo-%d@%s", td.ID, host))
+	cal.Time("DTSTAMP", td.UpdatedAt)
+	cal.Time("LAST-MODIFIED", td.UpdatedAt)
+	cal.Time("CREATED", td.CreatedAt)
+	if td.RecurrenceRule != nil {
+		cal.Property("RRULE", *td.RecurrenceRule)
+	}
+	cal.Text("CATEGORIES", td.Category)
+	if priority, ok := priorities[td.PriorityLevel]; ok {
+		cal.Property("PRIORITY", priority)
+	}
+	if td.Notes != "" {
+		cal.Text("DESCRIPTION", td.Notes)
+	}
+}
@@ -0,0 +1,88 @@
+package calendar
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type UserRepository interface {
+	GetByCalendarToken(ctx context.Context, token string) (domain.User, error)
+	GetCalendarToken(ctx context.Context, id int64) (*string, error)
+	SetCalendarToken(ctx context.Context, id int64, token *string, updatedAt time.Time) error
+}
+
+type TodoRepository interface {
+	GetCalendar(ctx context.Context, userID int64) ([]domain.Todo, error)
+}
+
+// CalendarService serves the todos of a user as a calendar feed. The feed is
+// read by calendar clients that cannot log in, so it is authenticated by a
+// secret token in its URL instead.
+type CalendarService struct {
+	userRepository UserRepository
+	todoRepository TodoRepository
+}
+
+func NewCalendarService(ur UserRepository, td TodoRepository) *CalendarService {
+	return &CalendarService{
+		userRepository: ur,
+		todoRepository: td,
+	}
+}
+
+// Feed returns the todos of the user the token belongs to.
+func (c *CalendarService) Feed(ctx context.Context, token string) (res []domain.Todo, err error) {
+	user, err := c.userRepository.GetByCalendarToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.todoRepository.GetCalendar(ctx, user.ID)
+}
+
+// GetToken returns the user's feed token, or ErrNotFound while the feed is
+// turned off.
+func (c *CalendarService) GetToken(ctx context.Context, userID int64) (res string, err error) {
+	token, err := c.userRepository.GetCalendarToken(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if token == nil {
+		return "", domain.ErrNotFound
+	}
+
+	return *token, nil
+}
+
+// RegenerateToken gives the user a new feed token, turning the feed on. The
+// previous URL stops working.
+func (c *CalendarService) RegenerateToken(ctx context.Context, userID int64) (res string, err error) {
+	res, err = generateToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err = c.userRepository.SetCalendarToken(ctx, userID, &res, time.Now()); err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// RevokeToken turns the feed off.
+func (c *CalendarService) RevokeToken(ctx context.Context, userID int64) (err error) {
+	return c.userRepository.SetCalendarToken(ctx, userID, nil, time.Now())
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,29 @@
+package comment
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern matches @username when the @ does not continue a word, so
+// e-mail addresses in a comment are not taken for mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@(\w[\w.\-]*)`)
+
+// parseMentions returns the distinct usernames mentioned in body, in the
+// order they first appear.
+func parseMentions(body string) []string {
+	var usernames []string
+	seen := map[string]bool{}
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		username := strings.TrimRight(match[1], ".-")
+		if seen[username] {
+			continue
+		}
+
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+
+	return usernames
+}
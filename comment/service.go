@@ -0,0 +1,238 @@
+package comment
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type CommentRepository interface {
+	GetByTodoID(ctx context.Context, todoID int64, cursor string, num int64) ([]domain.Comment, string, error)
+	GetByID(ctx context.Context, id int64) (domain.Comment, error)
+	Store(ctx context.Context, c *domain.Comment) error
+	Update(ctx context.Context, c *domain.Comment) error
+	Delete(ctx context.Context, id int64) error
+	GetMentions(ctx context.Context, commentIDs []int64) ([]domain.Mention, error)
+	ReplaceMentions(ctx context.Context, commentID int64, userIDs []int64) error
+}
+
+type UserRepository interface {
+	GetByUsernames(ctx context.Context, usernames []string) ([]domain.User, error)
+}
+
+type TodoAuthorizer interface {
+	Authorize(ctx context.Context, userID int64, id int64, required domain.ListRole) (domain.Todo, error)
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, n domain.Notification) error
+}
+
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type CommentService struct {
+	commentRepository CommentRepository
+	userRepository    UserRepository
+	todoAuthorizer    TodoAuthorizer
+	notifier          Notifier
+	transactor        Transactor
+}
+
+func NewCommentService(cr CommentRepository, ur UserRepository, ta TodoAuthorizer, n Notifier, tx Transactor) *CommentService {
+	return &CommentService{
+		commentRepository: cr,
+		userRepository:    ur,
+		todoAuthorizer:    ta,
+		notifier:          n,
+		transactor:        tx,
+	}
+}
+
+func (cs *CommentService) GetByTodoID(ctx context.Context, userID int64, todoID int64, cursor string, num int64) (res []domain.Comment, nextCursor string, err error) {
+	if _, err = cs.todoAuthorizer.Authorize(ctx, userID, todoID, domain.RoleViewer); err != nil {
+		return
+	}
+
+	res, nextCursor, err = cs.commentRepository.GetByTodoID(ctx, todoID, cursor, num)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = cs.fillMentions(ctx, res)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return
+}
+
+// Store adds a comment to the todo. Everyone who can see a todo may comment
+// on it, viewers included.
+func (cs *CommentService) Store(ctx context.Context, userID int64, todoID int64, c *domain.Comment) (err error) {
+	td, err := cs.todoAuthorizer.Authorize(ctx, userID, todoID, domain.RoleViewer)
+	if err != nil {
+		return
+	}
+
+	c.TodoID = todoID
+	c.UserID = userID
+	c.CreatedAt = time.Now()
+	c.UpdatedAt = c.CreatedAt
+
+	return cs.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := cs.commentRepository.Store(ctx, c); err != nil {
+			return err
+		}
+
+		return cs.storeMentions(ctx, td, c)
+	})
+}
+
+// Update changes the body of a comment. Only its author may edit it.
+func (cs *CommentService) Update(ctx context.Context, userID int64, todoID int64, c *domain.Comment) (err error) {
+	td, existedComment, err := cs.getOwnComment(ctx, userID, todoID, c.ID)
+	if err != nil {
+		return
+	}
+
+	c.TodoID = existedComment.TodoID
+	c.UserID = existedComment.UserID
+	c.CreatedAt = existedComment.CreatedAt
+	c.UpdatedAt = time.Now()
+
+	return cs.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := cs.commentRepository.Update(ctx, c); err != nil {
+			return err
+		}
+
+		return cs.storeMentions(ctx, td, c)
+	})
+}
+
+// Delete removes a comment. Only its author may delete it.
+func (cs *CommentService) Delete(ctx context.Context, userID int64, todoID int64, id int64) (err error) {
+	if _, _, err = cs.getOwnComment(ctx, userID, todoID, id); err != nil {
+		return
+	}
+
+	return cs.commentRepository.Delete(ctx, id)
+}
+
+func (cs *CommentService) getOwnComment(ctx context.Context, userID int64, todoID int64, id int64) (td domain.Todo, res domain.Comment, err error) {
+	td, err = cs.todoAuthorizer.Authorize(ctx, userID, todoID, domain.RoleViewer)
+	if err != nil {
+		return
+	}
+
+	res, err = cs.commentRepository.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if res.TodoID != todoID {
+		return domain.Todo{}, domain.Comment{}, domain.ErrNotFound
+	}
+	if res.UserID != userID {
+		return domain.Todo{}, domain.Comment{}, domain.ErrForbidden
+	}
+
+	return
+}
+
+// storeMentions records the users mentioned in the comment and notifies
+// those who were not mentioned in it before, other than the author. Unknown
+// usernames and users who cannot see the todo are ignored, so a mention never
+// reveals the todo to someone outside it.
+func (cs *CommentService) storeMentions(ctx context.Context, td domain.Todo, c *domain.Comment) (err error) {
+	c.Mentions = []domain.Mention{}
+
+	previous, err := cs.commentRepository.GetMentions(ctx, []int64{c.ID})
+	if err != nil {
+		return
+	}
+
+	usernames := parseMentions(c.Body)
+	if len(usernames) == 0 {
+		return cs.commentRepository.ReplaceMentions(ctx, c.ID, nil)
+	}
+
+	users, err := cs.userRepository.GetByUsernames(ctx, usernames)
+	if err != nil {
+		return
+	}
+
+	userIDs := []int64{}
+	for _, u := range users {
+		_, err = cs.todoAuthorizer.Authorize(ctx, u.ID, c.TodoID, domain.RoleViewer)
+		if err == domain.ErrNotFound || err == domain.ErrForbidden {
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		userIDs = append(userIDs, u.ID)
+		c.Mentions = append(c.Mentions, domain.Mention{CommentID: c.ID, UserID: u.ID, Username: u.Username})
+	}
+
+	if err = cs.commentRepository.ReplaceMentions(ctx, c.ID, userIDs); err != nil {
+		return
+	}
+
+	notified := map[int64]bool{c.UserID: true}
+	for _, m := range previous {
+		notified[m.UserID] = true
+	}
+
+	for _, m := range c.Mentions {
+		if notified[m.UserID] {
+			continue
+		}
+
+		err = cs.notifier.Notify(ctx, domain.Notification{
+			UserID:    m.UserID,
+			Kind:      domain.NotificationMention,
+			Title:     "You were mentioned on: " + td.Text,
+			Body:      c.Body,
+			TodoID:    &td.ID,
+			CreatedAt: c.UpdatedAt,
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (cs *CommentService) fillMentions(ctx context.Context, comments []domain.Comment) (err error) {
+	if len(comments) == 0 {
+		return
+	}
+
+	ids := make([]int64, 0, len(comments))
+	for _, c := range comments {
+		ids = append(ids, c.ID)
+	}
+
+	mentions, err := cs.commentRepository.GetMentions(ctx, ids)
+	if err != nil {
+		return
+	}
+
+	byComment := map[int64][]domain.Mention{}
+	for _, m := range mentions {
+		byComment[m.CommentID] = append(byComment[m.CommentID], m)
+	}
+
+	for i := range comments {
+		comments[i].Mentions = byComment[comments[i].ID]
+		if comments[i].Mentions == nil {
+			comments[i].Mentions = []domain.Mention{}
+		}
+	}
+
+	return
+}
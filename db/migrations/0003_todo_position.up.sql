@@ -0,0 +1,11 @@
+ALTER TABLE todos ADD COLUMN position DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+UPDATE todos t
+SET position = r.rn * 1024
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY user_id, category ORDER BY created_at, id) AS rn
+    FROM todos
+) r
+WHERE t.id = r.id;
+
+CREATE INDEX todos_user_category_position_idx ON todos (user_id, category, position);
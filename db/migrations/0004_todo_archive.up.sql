@@ -0,0 +1,8 @@
+ALTER TABLE todos ADD COLUMN archived_at TIMESTAMPTZ;
+ALTER TABLE todos ADD COLUMN completed_at TIMESTAMPTZ;
+
+UPDATE todos SET completed_at = updated_at WHERE completed;
+
+CREATE INDEX todos_auto_archive_idx ON todos (completed_at) WHERE completed AND archived_at IS NULL AND deleted_at IS NULL;
+
+ALTER TABLE users ADD COLUMN auto_archive_days INTEGER;
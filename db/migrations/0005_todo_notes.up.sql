@@ -0,0 +1 @@
+ALTER TABLE todos ADD COLUMN notes TEXT NOT NULL DEFAULT '';
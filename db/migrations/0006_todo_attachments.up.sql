@@ -0,0 +1,15 @@
+-- todo_id is cleared rather than cascaded when a todo is purged, so the
+-- background sweep can still find and delete the stored blob.
+CREATE TABLE todo_attachments (
+    id BIGSERIAL PRIMARY KEY,
+    todo_id BIGINT REFERENCES todos (id) ON DELETE SET NULL,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX todo_attachments_todo_id_idx ON todo_attachments (todo_id);
+CREATE INDEX todo_attachments_orphan_idx ON todo_attachments (id) WHERE todo_id IS NULL;
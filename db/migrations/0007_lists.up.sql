@@ -0,0 +1,23 @@
+CREATE TYPE list_role AS ENUM('viewer', 'editor', 'owner');
+
+CREATE TABLE lists (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE list_members (
+    list_id BIGINT NOT NULL REFERENCES lists (id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role list_role NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (list_id, user_id)
+);
+
+CREATE INDEX list_members_user_id_idx ON list_members (user_id);
+
+-- Deleting a list hands its todos back to their creators instead of losing them.
+ALTER TABLE todos ADD COLUMN list_id BIGINT REFERENCES lists (id) ON DELETE SET NULL;
+
+CREATE INDEX todos_list_id_idx ON todos (list_id) WHERE list_id IS NOT NULL;
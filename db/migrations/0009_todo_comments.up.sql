@@ -0,0 +1,18 @@
+CREATE TABLE todo_comments (
+    id BIGSERIAL PRIMARY KEY,
+    todo_id BIGINT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX todo_comments_todo_id_created_at_idx ON todo_comments (todo_id, created_at);
+
+CREATE TABLE comment_mentions (
+    comment_id BIGINT NOT NULL REFERENCES todo_comments (id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX comment_mentions_user_id_idx ON comment_mentions (user_id);
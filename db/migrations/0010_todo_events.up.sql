@@ -0,0 +1,12 @@
+-- changes maps each modified field to its old and new value.
+CREATE TABLE todo_events (
+    id BIGSERIAL PRIMARY KEY,
+    todo_id BIGINT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    actor_id BIGINT NOT NULL REFERENCES users (id),
+    type VARCHAR(20) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX todo_events_todo_id_created_at_idx ON todo_events (todo_id, created_at DESC);
+CREATE INDEX todo_events_created_at_idx ON todo_events (created_at DESC);
@@ -0,0 +1,13 @@
+ALTER TABLE users ADD COLUMN email VARCHAR(255);
+
+-- A reminder is due offset_minutes before the todo's date. sent_for holds
+-- the date it was last sent for, so moving the todo re-arms the reminder.
+CREATE TABLE todo_reminders (
+    id BIGSERIAL PRIMARY KEY,
+    todo_id BIGINT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    offset_minutes INT NOT NULL CHECK (offset_minutes >= 0),
+    sent_for TIMESTAMPTZ,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (todo_id, offset_minutes)
+);
@@ -0,0 +1,5 @@
+-- The secret part of the user's calendar feed URL; NULL while the feed is off.
+ALTER TABLE users ADD COLUMN calendar_token VARCHAR(64) UNIQUE;
+
+-- An iCalendar RRULE value, e.g. FREQ=WEEKLY;BYDAY=MO, for repeating todos.
+ALTER TABLE todos ADD COLUMN recurrence_rule VARCHAR(255);
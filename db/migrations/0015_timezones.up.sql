@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- An all-day todo is due on a calendar day rather than at an instant. Its
+-- date holds midnight UTC of that day, which is read in the user's zone.
+ALTER TABLE todos ADD COLUMN all_day BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+-- Completions per day, the streak and the average time to complete read a
+-- user's completed todos by completion time.
+CREATE INDEX todos_user_completed_at_idx ON todos (user_id, completed_at) WHERE completed AND deleted_at IS NULL;
+
+-- Counts a user's overdue todos without reading the completed ones.
+CREATE INDEX todos_user_open_date_idx ON todos (user_id, date) WHERE NOT completed AND deleted_at IS NULL AND archived_at IS NULL;
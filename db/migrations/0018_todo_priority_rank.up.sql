@@ -0,0 +1,12 @@
+-- Priorities become a rank from 0 (P0, urgent) to 4 (P4, lowest). The API
+-- keeps naming them; the rank is what todos are sorted by.
+ALTER TABLE todos ADD COLUMN priority SMALLINT;
+
+UPDATE todos SET priority = CASE priority_level WHEN 'high' THEN 1 WHEN 'medium' THEN 2 ELSE 3 END;
+
+ALTER TABLE todos ALTER COLUMN priority SET NOT NULL,
+    ADD CONSTRAINT todos_priority_check CHECK (priority BETWEEN 0 AND 4);
+
+ALTER TABLE todos DROP COLUMN priority_level;
+
+DROP TYPE priority_level;
@@ -0,0 +1,16 @@
+ALTER TABLE todos ADD COLUMN estimate_minutes INT CHECK (estimate_minutes >= 0);
+
+-- A time entry without ended_at is a running timer; a user has at most one.
+CREATE TABLE time_entries (
+    id BIGSERIAL PRIMARY KEY,
+    todo_id BIGINT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ CHECK (ended_at >= started_at),
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX time_entries_todo_id_idx ON time_entries (todo_id);
+CREATE INDEX time_entries_user_id_started_at_idx ON time_entries (user_id, started_at);
+CREATE UNIQUE INDEX time_entries_running_idx ON time_entries (user_id) WHERE ended_at IS NULL;
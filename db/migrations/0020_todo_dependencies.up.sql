@@ -0,0 +1,10 @@
+-- todo_id is blocked by blocked_by_id until that todo is completed.
+CREATE TABLE todo_dependencies (
+    todo_id BIGINT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    blocked_by_id BIGINT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (todo_id, blocked_by_id),
+    CHECK (todo_id <> blocked_by_id)
+);
+
+CREATE INDEX todo_dependencies_blocked_by_id_idx ON todo_dependencies (blocked_by_id);
@@ -0,0 +1,17 @@
+-- A due reminder is marked sent and queued here once per channel in one
+-- transaction. The dispatcher delivers each message on its own, so a channel
+-- that fails is retried without sending the reminder again over the others.
+CREATE TABLE reminder_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    reminder_id BIGINT REFERENCES todo_reminders (id) ON DELETE SET NULL,
+    channel VARCHAR(50) NOT NULL,
+    notification JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    failed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX reminder_outbox_pending_idx ON reminder_outbox (next_attempt_at) WHERE delivered_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,3 @@
+-- A todo may have no due date. Such todos are never today, upcoming or
+-- overdue, send no reminders and sort after the dated ones.
+ALTER TABLE todos ALTER COLUMN date DROP NOT NULL;
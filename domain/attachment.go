@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+)
+
+type Attachment struct {
+	ID          int64     `json:"id"`
+	TodoID      *int64    `json:"todo_id"`
+	UserID      int64     `json:"user_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
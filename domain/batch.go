@@ -0,0 +1,39 @@
+package domain
+
+type BatchOperationType string
+
+const (
+	BatchCreate   BatchOperationType = "create"
+	BatchUpdate   BatchOperationType = "update"
+	BatchDelete   BatchOperationType = "delete"
+	BatchComplete BatchOperationType = "complete"
+)
+
+type BatchResultStatus string
+
+const (
+	BatchStatusOK         BatchResultStatus = "ok"
+	BatchStatusFailed     BatchResultStatus = "failed"
+	BatchStatusRolledBack BatchResultStatus = "rolled_back"
+	BatchStatusSkipped    BatchResultStatus = "skipped"
+)
+
+type BatchOperation struct {
+	Op   BatchOperationType `json:"op" validate:"required,oneof=create update delete complete"`
+	ID   int64              `json:"id"`
+	Todo *Todo              `json:"todo"`
+}
+
+type BatchRequest struct {
+	Atomic     bool             `json:"atomic"`
+	Operations []BatchOperation `json:"operations" validate:"required,min=1,max=100,dive"`
+}
+
+type BatchResult struct {
+	Index  int                `json:"index"`
+	Op     BatchOperationType `json:"op"`
+	ID     int64              `json:"id,omitempty"`
+	Status BatchResultStatus  `json:"status"`
+	Error  string             `json:"error,omitempty"`
+	Data   *Todo              `json:"data,omitempty"`
+}
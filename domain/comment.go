@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+)
+
+type Comment struct {
+	ID        int64     `json:"id"`
+	TodoID    int64     `json:"todo_id"`
+	UserID    int64     `json:"user_id"`
+	Body      string    `json:"body" validate:"required,max=5000"`
+	Mentions  []Mention `json:"mentions"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Mention records that a comment @mentions a user.
+type Mention struct {
+	CommentID int64  `json:"-"`
+	UserID    int64  `json:"user_id"`
+	Username  string `json:"username"`
+}
@@ -8,5 +8,13 @@ var (
 	ErrConflict            = errors.New("your Item already exist")
 	ErrBadParamInput       = errors.New("given Param is not valid")
 	ErrCredential          = errors.New("your Credential is invalid")
-	ErrUsernameTaken	   = errors.New("your Username is already taken")
+	ErrForbidden           = errors.New("you are not allowed to do this")
+	ErrUsernameTaken       = errors.New("your Username is already taken")
+	ErrFileTooLarge        = errors.New("your File is too large")
+	ErrUnsupportedMedia    = errors.New("your File type is not supported")
+	ErrAssigneeAccess      = errors.New("the Assignee has no access to this todo")
+	ErrLastOwner           = errors.New("a List needs at least one owner")
+	ErrBatchAborted        = errors.New("batch was rolled back because an operation failed")
+	ErrDependencyCycle     = errors.New("the Dependency would create a cycle")
+	ErrWebhookAddress      = errors.New("the Webhook URL must point to a public address")
 )
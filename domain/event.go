@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+)
+
+type TodoEventType string
+
+const (
+	TodoCreated  TodoEventType = "created"
+	TodoUpdated  TodoEventType = "updated"
+	TodoDeleted  TodoEventType = "deleted"
+	TodoRestored TodoEventType = "restored"
+	// TodoUnblocked is recorded when the last open todo blocking a todo is
+	// completed.
+	TodoUnblocked TodoEventType = "unblocked"
+)
+
+// FieldChange is the value of a todo field before and after an update.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// TodoEvent is an entry in the change history of a todo. Changes is only
+// filled for updates and is keyed by the JSON name of the field.
+type TodoEvent struct {
+	ID        int64                  `json:"id"`
+	TodoID    int64                  `json:"todo_id"`
+	ActorID   int64                  `json:"actor_id"`
+	Type      TodoEventType          `json:"type"`
+	Changes   map[string]FieldChange `json:"changes"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// StreamEvent is pushed to the connected clients of every user who can see
+// the todo. Todo is left out for deletions and may be left out when the
+// event is too large to relay.
+type StreamEvent struct {
+	Type      TodoEventType `json:"type"`
+	TodoID    int64         `json:"todo_id"`
+	ActorID   int64         `json:"actor_id"`
+	Todo      *Todo         `json:"todo,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
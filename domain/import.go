@@ -0,0 +1,16 @@
+package domain
+
+// ImportRowError explains why a record of an import file was rejected. Row
+// counts the records from 1, not counting a CSV header.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	DryRun   bool             `json:"dry_run"`
+	Total    int              `json:"total"`
+	Valid    int              `json:"valid"`
+	Imported int64            `json:"imported"`
+	Errors   []ImportRowError `json:"errors"`
+}
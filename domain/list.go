@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+)
+
+type ListRole string
+
+const (
+	RoleViewer ListRole = "viewer"
+	RoleEditor ListRole = "editor"
+	RoleOwner  ListRole = "owner"
+)
+
+var listRoleRank = map[ListRole]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+// Allows reports whether a member with role r may do what requires the
+// required role. Owners can do everything editors can, editors everything
+// viewers can.
+func (r ListRole) Allows(required ListRole) bool {
+	return listRoleRank[r] > 0 && listRoleRank[r] >= listRoleRank[required]
+}
+
+type List struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name" validate:"required,max=100"`
+	Role      ListRole  `json:"role,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ListMember struct {
+	ListID    int64     `json:"list_id"`
+	UserID    int64     `json:"user_id"`
+	Username  string    `json:"username"`
+	Name      string    `json:"name"`
+	Role      ListRole  `json:"role" validate:"required,oneof=viewer editor owner"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListInvitation struct {
+	Username string   `json:"username" validate:"required"`
+	Role     ListRole `json:"role" validate:"required,oneof=viewer editor owner"`
+}
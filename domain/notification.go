@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+)
+
+type NotificationKind string
+
+const (
+	NotificationReminder   NotificationKind = "reminder"
+	NotificationAssignment NotificationKind = "assignment"
+	NotificationMention    NotificationKind = "mention"
+)
+
+// Notification is a message for a single user. ReadAt is nil while it is
+// unread in the user's inbox.
+type Notification struct {
+	ID        int64            `json:"id"`
+	UserID    int64            `json:"user_id"`
+	Kind      NotificationKind `json:"kind"`
+	Title     string           `json:"title"`
+	Body      string           `json:"body"`
+	TodoID    *int64           `json:"todo_id"`
+	ReadAt    *time.Time       `json:"read_at"`
+	CreatedAt time.Time        `json:"created_at"`
+}
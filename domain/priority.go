@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PriorityLevel is the name of a priority. Todos are stored and sorted by its
+// rank, from 0 (P0, urgent) to 4 (P4, lowest).
+type PriorityLevel string
+
+const (
+	Urgent PriorityLevel = "urgent"
+	High   PriorityLevel = "high"
+	Medium PriorityLevel = "medium"
+	Low    PriorityLevel = "low"
+	Lowest PriorityLevel = "lowest"
+)
+
+// priorities lists the levels by rank.
+var priorities = []PriorityLevel{Urgent, High, Medium, Low, Lowest}
+
+// Rank returns the rank of the level, or -1 when the level is unknown.
+func (p PriorityLevel) Rank() int {
+	for rank, level := range priorities {
+		if level == p {
+			return rank
+		}
+	}
+
+	return -1
+}
+
+// PriorityFromRank returns the level of a rank, or "" when it is out of
+// range.
+func PriorityFromRank(rank int) PriorityLevel {
+	if rank < 0 || rank >= len(priorities) {
+		return ""
+	}
+
+	return priorities[rank]
+}
+
+// ParsePriority reads a level by its name, as "P0" to "P4" or as a rank "0"
+// to "4", ignoring case.
+func ParsePriority(s string) (PriorityLevel, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if level := PriorityLevel(s); level.Rank() >= 0 {
+		return level, true
+	}
+
+	rank, err := strconv.Atoi(strings.TrimPrefix(s, "p"))
+	if err != nil || PriorityFromRank(rank) == "" {
+		return "", false
+	}
+
+	return PriorityFromRank(rank), true
+}
+
+// UnmarshalJSON accepts whatever ParsePriority does, as a string or as a
+// number. Anything else is kept as is for validation to reject.
+func (p *PriorityLevel) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	if bytes.HasPrefix(b, []byte(`"`)) {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	} else if _, err := strconv.Atoi(s); err != nil {
+		return fmt.Errorf("invalid priority level %s", s)
+	}
+
+	if level, ok := ParsePriority(s); ok {
+		*p = level
+	} else {
+		*p = PriorityLevel(s)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+)
+
+// Reminder fires OffsetMinutes before the todo's date. RemindAt follows the
+// todo's current date and is nil while the todo has none.
+type Reminder struct {
+	ID            int64      `json:"id"`
+	TodoID        int64      `json:"todo_id"`
+	OffsetMinutes int        `json:"offset_minutes"`
+	RemindAt      *time.Time `json:"remind_at"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ReminderRequest replaces the reminders of a todo, e.g. [1440, 60] for one
+// day and one hour before it is due.
+type ReminderRequest struct {
+	OffsetMinutes []int `json:"offset_minutes" validate:"max=10,dive,min=0,max=525600"`
+}
+
+// DueReminder is a reminder whose time has come. Todo only carries the id,
+// text, date, all-day flag, creator and assignee. Timezone is the zone of the
+// user the reminder goes to.
+type DueReminder struct {
+	Reminder Reminder
+	Todo     Todo
+	Timezone string
+}
+
+// ReminderMessage is a reminder waiting in the outbox to be delivered over
+// one channel.
+type ReminderMessage struct {
+	ID           int64
+	Channel      string
+	Notification Notification
+	Attempts     int
+}
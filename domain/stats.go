@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+)
+
+// StatsCount splits a number of todos by completion.
+type StatsCount struct {
+	Total     int64 `json:"total"`
+	Completed int64 `json:"completed"`
+	Open      int64 `json:"open"`
+}
+
+type DailyCount struct {
+	Date  string `json:"date"`
+	Count int64  `json:"count"`
+}
+
+// Stats summarises the todos a user created. Counts cover every todo that is
+// not deleted, archived ones included; the completions per day and the
+// average time to complete cover the days From to To in Timezone.
+type Stats struct {
+	Timezone          string                       `json:"timezone"`
+	From              string                       `json:"from"`
+	To                string                       `json:"to"`
+	Totals            StatsCount                   `json:"totals"`
+	ByCategory        map[string]StatsCount        `json:"by_category"`
+	ByPriority        map[PriorityLevel]StatsCount `json:"by_priority"`
+	CompletedPerDay   []DailyCount                 `json:"completed_per_day"`
+	CurrentStreak     int64                        `json:"current_streak"`
+	Overdue           int64                        `json:"overdue"`
+	AverageCompletion *int64                       `json:"average_completion_seconds"`
+}
+
+// ParseDateRange reads a range of days such as 2024-10-01 in loc, both ends
+// included. Without to the range ends today, and without from it spans days
+// days. It fails with ErrBadParamInput when the range is reversed or longer
+// than maxDays.
+func ParseDateRange(from string, to string, loc *time.Location, days int, maxDays int) (start time.Time, end time.Time, err error) {
+	now := time.Now().In(loc)
+	end = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if to != "" {
+		if end, err = time.ParseInLocation("2006-01-02", to, loc); err != nil {
+			return time.Time{}, time.Time{}, ErrBadParamInput
+		}
+	}
+
+	start = end.AddDate(0, 0, 1-days)
+	if from != "" {
+		if start, err = time.ParseInLocation("2006-01-02", from, loc); err != nil {
+			return time.Time{}, time.Time{}, ErrBadParamInput
+		}
+	}
+
+	if start.After(end) || !end.Before(start.AddDate(0, 0, maxDays)) {
+		return time.Time{}, time.Time{}, ErrBadParamInput
+	}
+
+	return
+}
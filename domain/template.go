@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+)
+
+// Template is a set of todos a user can create again and again, such as an
+// onboarding checklist. Instantiating a project template also creates a list,
+// named after the template, to hold its todos.
+type Template struct {
+	ID          int64          `json:"id"`
+	UserID      int64          `json:"user_id"`
+	Name        string         `json:"name" validate:"required,max=100"`
+	Description string         `json:"description" validate:"max=2000"`
+	Project     bool           `json:"project"`
+	Items       []TemplateItem `json:"items" validate:"required,min=1,max=100,dive"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// TemplateItem is a todo of a template. It is due DueOffsetDays after the
+// start date it is instantiated with, at DueTime in the user's time zone, or
+// all day when DueTime is empty.
+type TemplateItem struct {
+	Text            string        `json:"text" validate:"required,max=255"`
+	Notes           string        `json:"notes" validate:"max=20000"`
+	Category        string        `json:"category" validate:"required,max=100"`
+	PriorityLevel   PriorityLevel `json:"priority_level" validate:"required,oneof=urgent high medium low lowest"`
+	DueOffsetDays   int           `json:"due_offset_days" validate:"min=-3650,max=3650"`
+	DueTime         string        `json:"due_time" validate:"omitempty,datetime=15:04"`
+	EstimateMinutes *int          `json:"estimate_minutes" validate:"omitempty,min=0,max=525600"`
+}
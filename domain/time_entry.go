@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+)
+
+// TimeEntry is time a user spent on a todo. EndedAt is nil while the entry
+// is a running timer.
+type TimeEntry struct {
+	ID        int64      `json:"id"`
+	TodoID    int64      `json:"todo_id"`
+	UserID    int64      `json:"user_id"`
+	StartedAt time.Time  `json:"started_at" validate:"required"`
+	EndedAt   *time.Time `json:"ended_at" validate:"required,gtfield=StartedAt"`
+	Note      string     `json:"note" validate:"max=255"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type CategoryTime struct {
+	Category string `json:"category"`
+	Seconds  int64  `json:"seconds"`
+	Entries  int64  `json:"entries"`
+}
+
+// TimeReport sums the time a user tracked on entries started from From to To
+// in Timezone, by the category of their todos.
+type TimeReport struct {
+	Timezone     string         `json:"timezone"`
+	From         string         `json:"from"`
+	To           string         `json:"to"`
+	TotalSeconds int64          `json:"total_seconds"`
+	ByCategory   []CategoryTime `json:"by_category"`
+}
@@ -1,25 +1,110 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 )
 
-type PriorityLevel string
+type Todo struct {
+	ID              int64         `json:"id"`
+	Text            string        `json:"text" validate:"required,max=255"`
+	Notes           string        `json:"notes" validate:"max=20000"`
+	NotesHTML       string        `json:"notes_html,omitempty"`
+	Category        string        `json:"category" validate:"required,max=100"`
+	Date            *time.Time    `json:"date"`
+	AllDay          bool          `json:"all_day"`
+	PriorityLevel   PriorityLevel `json:"priority_level" validate:"required,oneof=urgent high medium low lowest"`
+	Completed       bool          `json:"completed"`
+	RecurrenceRule  *string       `json:"recurrence_rule" validate:"omitempty,max=255,printascii,contains=FREQ="`
+	EstimateMinutes *int          `json:"estimate_minutes" validate:"omitempty,min=0,max=525600"`
+	TrackedSeconds  int64         `json:"tracked_seconds"`
+	BlockedBy       []int64       `json:"blocked_by"`
+	Blocking        []int64       `json:"blocking"`
+	Position        float64       `json:"position"`
+	CompletedAt     *time.Time    `json:"completed_at,omitempty"`
+	ArchivedAt      *time.Time    `json:"archived_at,omitempty"`
+	UserID          int64         `json:"user_id" validate:"required"`
+	ListID          *int64        `json:"list_id"`
+	ListIDSet       bool          `json:"-"`
+	AssigneeID      *int64        `json:"assignee_id"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	DeletedAt       *time.Time    `json:"deleted_at,omitempty"`
+}
+
+// UnmarshalJSON also records in ListIDSet whether list_id was sent, so an
+// update can tell a todo moved out of its list from one that left it alone.
+func (td *Todo) UnmarshalJSON(b []byte) error {
+	type todo Todo
+	if err := json.Unmarshal(b, (*todo)(td)); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	_, td.ListIDSet = fields["list_id"]
+
+	return nil
+}
+
+type TodoSort string
 
 const (
-	Low    PriorityLevel = "low"
-	Medium PriorityLevel = "medium"
-	High   PriorityLevel = "high"
+	SortCreatedAt TodoSort = "created_at"
+	SortPosition  TodoSort = "position"
+	SortDate      TodoSort = "date"
+	SortPriority  TodoSort = "priority"
 )
 
-type Todo struct {
-	ID            int64         `json:"id"`
-	Text          string        `json:"text" validate:"required"`
-	Category      string        `json:"category" validate:"required"`
-	Date          time.Time     `json:"date" validate:"required"`
-	PriorityLevel PriorityLevel `json:"priority_level" validate:"required"`
-	Completed     bool          `json:"completed"`
-	UserID        int64         `json:"user_id" validate:"required"`
-	CreatedAt     time.Time     `json:"created_at"`
-	UpdatedAt     time.Time     `json:"updated_at"`
+// AssignedMe is the Assigned filter for todos assigned to the requesting user.
+const AssignedMe = "me"
+
+// Due filters compare dates with the current day in the user's time zone.
+const (
+	DueToday    = "today"
+	DueOverdue  = "overdue"
+	DueUpcoming = "upcoming"
+	DueNone     = "none"
+)
+
+// TodoFilter holds the optional criteria accepted by the todo list endpoint.
+type TodoFilter struct {
+	ListID        *int64        `json:"list_id,omitempty"`
+	Category      string        `json:"category,omitempty"`
+	PriorityLevel PriorityLevel `json:"priority_level,omitempty" validate:"omitempty,oneof=urgent high medium low lowest"`
+	Keyword       string        `json:"keyword,omitempty"`
+	Archived      bool          `json:"archived,omitempty"`
+	Assigned      string        `json:"assigned,omitempty" validate:"omitempty,oneof=me"`
+	Due           string        `json:"due,omitempty" validate:"omitempty,oneof=today overdue upcoming none"`
+	Blocked       *bool         `json:"blocked,omitempty"`
+	Sort          TodoSort      `json:"sort,omitempty" validate:"omitempty,oneof=created_at position date priority"`
+}
+
+// DependencyRequest makes a todo blocked by another one.
+type DependencyRequest struct {
+	BlockedByID int64 `json:"blocked_by_id" validate:"required"`
+}
+
+// MoveRequest places a todo right after the After anchor and/or right
+// before the Before anchor. Anchors must share the todo's category.
+type MoveRequest struct {
+	Before *int64 `json:"before"`
+	After  *int64 `json:"after"`
+}
+
+type AssignRequest struct {
+	AssigneeID int64 `json:"assignee_id" validate:"required"`
+}
+
+// QuickAddRequest is a todo written as one line of text, such as
+// "buy milk tomorrow 5pm #errands !high". Dates are read in Timezone, an IANA
+// zone name, and in the user's zone when it is empty. Unless Confirm is set
+// the todo is only previewed.
+type QuickAddRequest struct {
+	Text     string `json:"text" validate:"required,max=1000"`
+	Timezone string `json:"timezone" validate:"omitempty,timezone"`
+	ListID   *int64 `json:"list_id"`
+	Confirm  bool   `json:"confirm"`
 }
@@ -17,3 +17,13 @@ type AuthCredentials struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
 }
+
+// UserSettings are the per-user preferences that can be changed through
+// /me/settings. AutoArchiveDays is nil when auto-archiving is disabled, Email
+// is nil when the user gets no e-mail notifications. Timezone is an IANA zone
+// name such as Europe/Berlin.
+type UserSettings struct {
+	AutoArchiveDays *int    `json:"auto_archive_days" validate:"omitempty,min=1,max=3650"`
+	Email           *string `json:"email" validate:"omitempty,email,max=255"`
+	Timezone        string  `json:"timezone" validate:"omitempty,timezone,max=64"`
+}
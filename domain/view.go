@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+)
+
+// SavedView is a named todo filter of a user, applied with
+// GET /todos?view=:id.
+type SavedView struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	Name      string     `json:"name" validate:"required,max=100"`
+	Filter    TodoFilter `json:"filter"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// SmartView is a view every user has. It is applied by its key, as in
+// GET /todos?view=today.
+type SmartView struct {
+	Key    string     `json:"key"`
+	Name   string     `json:"name"`
+	Filter TodoFilter `json:"filter"`
+}
+
+var SmartViews = []SmartView{
+	{Key: "today", Name: "Today", Filter: TodoFilter{Due: DueToday, Sort: SortDate}},
+	{Key: "upcoming", Name: "Upcoming", Filter: TodoFilter{Due: DueUpcoming, Sort: SortDate}},
+	{Key: "overdue", Name: "Overdue", Filter: TodoFilter{Due: DueOverdue, Sort: SortDate}},
+	{Key: "no-date", Name: "No date", Filter: TodoFilter{Due: DueNone}},
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookTodoCreated   WebhookEvent = "todo.created"
+	WebhookTodoCompleted WebhookEvent = "todo.completed"
+	WebhookTodoOverdue   WebhookEvent = "todo.overdue"
+	WebhookTodoUnblocked WebhookEvent = "todo.unblocked"
+)
+
+// Webhook is an endpoint registered by a user. Secret signs the deliveries;
+// it is only returned when the webhook is created. Active is left as it was
+// by an update that omits it.
+type Webhook struct {
+	ID        int64          `json:"id"`
+	UserID    int64          `json:"user_id"`
+	URL       string         `json:"url" validate:"required,http_url,max=2048"`
+	Secret    string         `json:"secret,omitempty" validate:"omitempty,min=16,max=255"`
+	Events    []WebhookEvent `json:"events" validate:"required,min=1,dive,oneof=todo.created todo.completed todo.overdue todo.unblocked"`
+	Active    *bool          `json:"active"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// WebhookPayload is the body sent to a webhook endpoint.
+type WebhookPayload struct {
+	Event     WebhookEvent `json:"event"`
+	CreatedAt time.Time    `json:"created_at"`
+	Todo      Todo         `json:"todo"`
+}
+
+// WebhookMessage is a payload waiting in the outbox to be delivered to an
+// endpoint.
+type WebhookMessage struct {
+	ID        int64
+	WebhookID int64
+	URL       string
+	Secret    string
+	Event     WebhookEvent
+	Payload   json.RawMessage
+	Attempts  int
+}
+
+// WebhookDelivery is one attempt to deliver a message. StatusCode is nil when
+// no response was received.
+type WebhookDelivery struct {
+	ID         int64        `json:"id"`
+	MessageID  int64        `json:"message_id"`
+	WebhookID  int64        `json:"webhook_id"`
+	Event      WebhookEvent `json:"event"`
+	Attempt    int          `json:"attempt"`
+	StatusCode *int         `json:"status_code"`
+	Error      string       `json:"error,omitempty"`
+	DurationMs int64        `json:"duration_ms"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
@@ -0,0 +1,231 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type Format string
+
+const (
+	JSON     Format = "json"
+	CSV      Format = "csv"
+	Markdown Format = "md"
+)
+
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case Markdown:
+		return "text/markdown; charset=utf-8"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// Columns is the header row of a CSV export.
+var Columns = []string{
+	"id", "text", "notes", "category", "date", "all_day", "priority_level", "completed", "completed_at",
+	"recurrence_rule", "estimate_minutes", "tracked_seconds", "list_id", "assignee_id", "archived_at", "created_at", "updated_at",
+}
+
+// Encoder writes todos one at a time. Close finishes the document and must
+// be called even when no todo was written.
+type Encoder interface {
+	Encode(td domain.Todo) error
+	Close() error
+}
+
+// NewEncoder returns an encoder for the format, or ErrBadParamInput when the
+// format is unknown.
+func NewEncoder(format Format, w io.Writer) (Encoder, error) {
+	switch format {
+	case JSON:
+		return &jsonEncoder{w: bufio.NewWriter(w)}, nil
+	case CSV:
+		return &csvEncoder{w: csv.NewWriter(w)}, nil
+	case Markdown:
+		return &markdownEncoder{w: bufio.NewWriter(w)}, nil
+	default:
+		return nil, domain.ErrBadParamInput
+	}
+}
+
+// jsonEncoder writes an array of todos in the same shape the API returns
+// them, one per line.
+type jsonEncoder struct {
+	w     *bufio.Writer
+	count int
+}
+
+func (e *jsonEncoder) Encode(td domain.Todo) error {
+	b, err := json.Marshal(td)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if e.count == 0 {
+		separator = "[\n"
+	}
+	e.count++
+
+	if _, err = e.w.WriteString(separator); err != nil {
+		return err
+	}
+	_, err = e.w.Write(b)
+
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	end := "\n]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+
+	if _, err := e.w.WriteString(end); err != nil {
+		return err
+	}
+
+	return e.w.Flush()
+}
+
+type csvEncoder struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (e *csvEncoder) Encode(td domain.Todo) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	return e.w.Write([]string{
+		strconv.FormatInt(td.ID, 10),
+		td.Text,
+		td.Notes,
+		td.Category,
+		formatTime(td.Date),
+		strconv.FormatBool(td.AllDay),
+		string(td.PriorityLevel),
+		strconv.FormatBool(td.Completed),
+		formatTime(td.CompletedAt),
+		formatString(td.RecurrenceRule),
+		formatMinutes(td.EstimateMinutes),
+		strconv.FormatInt(td.TrackedSeconds, 10),
+		formatID(td.ListID),
+		formatID(td.AssigneeID),
+		formatTime(td.ArchivedAt),
+		formatTime(&td.CreatedAt),
+		formatTime(&td.UpdatedAt),
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.header {
+		return nil
+	}
+	e.header = true
+
+	return e.w.Write(Columns)
+}
+
+// markdownEncoder writes a task list with the notes indented below each
+// todo.
+type markdownEncoder struct {
+	w      *bufio.Writer
+	header bool
+}
+
+func (e *markdownEncoder) Encode(td domain.Todo) error {
+	e.writeHeader()
+
+	check := " "
+	if td.Completed {
+		check = "x"
+	}
+	due := ""
+	switch {
+	case td.Date == nil:
+	case td.AllDay:
+		due = ", due " + td.Date.UTC().Format("2006-01-02")
+	default:
+		due = ", due " + td.Date.UTC().Format("2006-01-02 15:04 UTC")
+	}
+	fmt.Fprintf(e.w, "- [%s] %s (%s, %s%s)\n", check, strings.ReplaceAll(td.Text, "\n", " "), td.Category, td.PriorityLevel, due)
+
+	if td.Notes != "" {
+		for _, line := range strings.Split(td.Notes, "\n") {
+			fmt.Fprintf(e.w, "\n    %s", line)
+		}
+		e.w.WriteString("\n\n")
+	}
+
+	return nil
+}
+
+func (e *markdownEncoder) Close() error {
+	e.writeHeader()
+
+	return e.w.Flush()
+}
+
+func (e *markdownEncoder) writeHeader() {
+	if e.header {
+		return
+	}
+	e.header = true
+
+	e.w.WriteString("# Todos\n\n")
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatString(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func formatMinutes(minutes *int) string {
+	if minutes == nil {
+		return ""
+	}
+
+	return strconv.Itoa(*minutes)
+}
+
+func formatID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+
+	return strconv.FormatInt(*id, 10)
+}
@@ -0,0 +1,91 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineLength is the longest content line, in octets, allowed by RFC 5545
+// before it has to be folded.
+const maxLineLength = 75
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "")
+
+// Writer writes iCalendar content lines, folding long lines and ending each
+// with CRLF. The first write error is kept and returned by Flush.
+type Writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		w: bufio.NewWriter(w),
+	}
+}
+
+func (w *Writer) Begin(component string) {
+	w.Property("BEGIN", component)
+}
+
+func (w *Writer) End(component string) {
+	w.Property("END", component)
+}
+
+// Property writes value as is. Use Text for values that come from users.
+func (w *Writer) Property(name string, value string) {
+	w.line(name + ":" + value)
+}
+
+// Text writes a TEXT value, escaping the characters that are special in
+// iCalendar.
+func (w *Writer) Text(name string, value string) {
+	w.Property(name, textEscaper.Replace(value))
+}
+
+// Time writes a DATE-TIME value in UTC.
+func (w *Writer) Time(name string, t time.Time) {
+	w.Property(name, t.UTC().Format("20060102T150405Z"))
+}
+
+// Date writes a DATE value, the calendar day of t in UTC.
+func (w *Writer) Date(name string, t time.Time) {
+	w.Property(name+";VALUE=DATE", t.UTC().Format("20060102"))
+}
+
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	return w.w.Flush()
+}
+
+// line folds the line into chunks of at most maxLineLength octets without
+// splitting a UTF-8 sequence. Continuation lines start with a space.
+func (w *Writer) line(s string) {
+	limit := maxLineLength
+	for len(s) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(s[i]) {
+			i--
+		}
+
+		w.write(s[:i] + "\r\n ")
+		s = s[i:]
+		limit = maxLineLength - 1
+	}
+
+	w.write(s + "\r\n")
+}
+
+func (w *Writer) write(s string) {
+	if w.err != nil {
+		return
+	}
+
+	_, w.err = w.w.WriteString(s)
+}
@@ -0,0 +1,135 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+// Fields are the todo fields a CSV import can fill. Unless mapped to another
+// column, each is read from the column of the same name, as written by the
+// CSV export.
+var Fields = []string{"text", "notes", "category", "date", "all_day", "priority_level", "completed", "completed_at", "recurrence_rule", "estimate_minutes"}
+
+func parseCSV(r io.Reader, mapping map[string]string) (res []Row, err error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// Spreadsheets like to start the file with a byte order mark.
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	index := make(map[string]int, len(Fields))
+	for _, field := range Fields {
+		name := field
+		if mapped, ok := mapping[field]; ok {
+			name = mapped
+		}
+		if i, ok := columns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			index[field] = i
+		}
+	}
+
+	for number := 1; ; number++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		value := func(field string) string {
+			i, ok := index[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		td, err := csvTodo(value)
+		res = append(res, Row{Number: number, Todo: td, Err: err})
+	}
+
+	return
+}
+
+func csvTodo(value func(field string) string) (td domain.Todo, err error) {
+	td = domain.Todo{
+		Text:          value("text"),
+		Notes:         value("notes"),
+		Category:      value("category"),
+		PriorityLevel: domain.PriorityLevel(strings.ToLower(value("priority_level"))),
+	}
+	if level, ok := domain.ParsePriority(value("priority_level")); ok {
+		td.PriorityLevel = level
+	}
+
+	if s := value("date"); s != "" {
+		date, err := parseTime(s)
+		if err != nil {
+			return domain.Todo{}, err
+		}
+		td.Date = &date
+	}
+	if s := value("all_day"); s != "" {
+		if td.AllDay, err = parseBool(s); err != nil {
+			return domain.Todo{}, err
+		}
+	}
+	if s := value("completed"); s != "" {
+		if td.Completed, err = parseBool(s); err != nil {
+			return domain.Todo{}, err
+		}
+	}
+	if s := value("completed_at"); s != "" {
+		completedAt, err := parseTime(s)
+		if err != nil {
+			return domain.Todo{}, err
+		}
+		td.CompletedAt = &completedAt
+	}
+	if s := value("estimate_minutes"); s != "" {
+		minutes, err := strconv.Atoi(s)
+		if err != nil {
+			return domain.Todo{}, errors.New("invalid estimate_minutes " + strconv.Quote(s))
+		}
+		td.EstimateMinutes = &minutes
+	}
+	if s := value("recurrence_rule"); s != "" {
+		td.RecurrenceRule = &s
+	}
+
+	return
+}
+
+// parseBool also accepts the yes/no and x spellings found in spreadsheets.
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "yes", "y", "x":
+		return true, nil
+	case "no", "n":
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, errors.New("invalid boolean value " + strconv.Quote(s))
+	}
+
+	return b, nil
+}
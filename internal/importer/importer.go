@@ -0,0 +1,132 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type Format string
+
+const (
+	JSON    Format = "json"
+	CSV     Format = "csv"
+	Todoist Format = "todoist"
+	Trello  Format = "trello"
+)
+
+// Row is one todo read from an import file. Number counts the records of the
+// file from 1, not counting a CSV header, so errors can be traced back to the
+// source. Err is set when the record could not be turned into a todo.
+type Row struct {
+	Number int
+	Todo   domain.Todo
+	Err    error
+}
+
+// Parse reads every record of the file. mapping names the CSV column to read
+// each todo field from and is ignored for the other formats. The error is
+// only set when the file as a whole cannot be read.
+func Parse(format Format, r io.Reader, mapping map[string]string) ([]Row, error) {
+	switch format {
+	case JSON:
+		return parseJSON(r)
+	case CSV:
+		return parseCSV(r, mapping)
+	case Todoist:
+		return parseTodoist(r)
+	case Trello:
+		return parseTrello(r)
+	default:
+		return nil, domain.ErrBadParamInput
+	}
+}
+
+// parseJSON reads an array of todos as written by the JSON export. Records
+// are decoded one by one so a bad record only fails its own row. Ids,
+// owners, lists and positions are left behind; the todos are new.
+func parseJSON(r io.Reader) (res []Row, err error) {
+	dec := json.NewDecoder(r)
+	if err = expectDelim(dec, '['); err != nil {
+		return nil, err
+	}
+
+	for number := 1; dec.More(); number++ {
+		var raw json.RawMessage
+		if err = dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("record %d: %w", number, err)
+		}
+
+		var td domain.Todo
+		if err := json.Unmarshal(raw, &td); err != nil {
+			res = append(res, Row{Number: number, Err: err})
+			continue
+		}
+
+		res = append(res, Row{Number: number, Todo: domain.Todo{
+			Text:            td.Text,
+			Notes:           td.Notes,
+			Category:        td.Category,
+			Date:            td.Date,
+			AllDay:          td.AllDay,
+			PriorityLevel:   td.PriorityLevel,
+			Completed:       td.Completed,
+			CompletedAt:     td.CompletedAt,
+			RecurrenceRule:  td.RecurrenceRule,
+			EstimateMinutes: td.EstimateMinutes,
+		}})
+	}
+
+	if err = expectDelim(dec, ']'); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %q", delim)
+	}
+
+	return nil
+}
+
+// isArray reports whether the JSON document in r starts with an array. The
+// returned reader still yields the whole document.
+func isArray(r io.Reader) (bool, io.Reader, error) {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return false, br, err
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			br.ReadByte()
+		default:
+			return b[0] == '[', br, nil
+		}
+	}
+}
+
+// parseTime accepts the date formats commonly found in exports. Dates
+// without a zone are taken as UTC.
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
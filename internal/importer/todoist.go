@@ -0,0 +1,119 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type todoistTask struct {
+	Content     string   `json:"content"`
+	Description string   `json:"description"`
+	ProjectID   string   `json:"project_id"`
+	Priority    int      `json:"priority"`
+	Labels      []string `json:"labels"`
+	IsCompleted bool     `json:"is_completed"`
+	Checked     bool     `json:"checked"`
+	Due         *struct {
+		Date     string `json:"date"`
+		Datetime string `json:"datetime"`
+	} `json:"due"`
+}
+
+type todoistProject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// parseTodoist reads either the task array of the Todoist REST API or a sync
+// API dump with "items" and "projects". Todos are filed under their project
+// name when it is known, else under their first label.
+func parseTodoist(r io.Reader) (res []Row, err error) {
+	array, r, err := isArray(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []json.RawMessage
+	projects := map[string]string{}
+	if array {
+		err = json.NewDecoder(r).Decode(&tasks)
+	} else {
+		var dump struct {
+			Items    []json.RawMessage `json:"items"`
+			Projects []todoistProject  `json:"projects"`
+		}
+		err = json.NewDecoder(r).Decode(&dump)
+		tasks = dump.Items
+		for _, p := range dump.Projects {
+			projects[p.ID] = p.Name
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i, raw := range tasks {
+		row := Row{Number: i + 1}
+
+		var task todoistTask
+		if err := json.Unmarshal(raw, &task); err != nil {
+			row.Err = err
+			res = append(res, row)
+			continue
+		}
+
+		row.Todo, row.Err = todoistTodo(task, projects)
+		res = append(res, row)
+	}
+
+	return
+}
+
+func todoistTodo(task todoistTask, projects map[string]string) (td domain.Todo, err error) {
+	td = domain.Todo{
+		Text:          task.Content,
+		Notes:         task.Description,
+		Category:      projects[task.ProjectID],
+		PriorityLevel: todoistPriority(task.Priority),
+		Completed:     task.IsCompleted || task.Checked,
+	}
+	if td.Category == "" && len(task.Labels) > 0 {
+		td.Category = task.Labels[0]
+	}
+	if td.Category == "" {
+		td.Category = "Todoist"
+	}
+
+	// A due date without a time is all-day.
+	if task.Due != nil {
+		due := task.Due.Datetime
+		if due == "" {
+			due = task.Due.Date
+			td.AllDay = true
+		}
+		date, err := parseTime(due)
+		if err != nil {
+			return domain.Todo{}, fmt.Errorf("due: %w", err)
+		}
+		td.Date = &date
+	}
+
+	return
+}
+
+// todoistPriority maps Todoist's 4 (p1, urgent) to 1 (p4, normal) scale.
+func todoistPriority(priority int) domain.PriorityLevel {
+	switch {
+	case priority >= 4:
+		return domain.Urgent
+	case priority == 3:
+		return domain.High
+	case priority == 2:
+		return domain.Medium
+	default:
+		return domain.Low
+	}
+}
@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type trelloBoard struct {
+	Name  string `json:"name"`
+	Lists []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Closed bool   `json:"closed"`
+	} `json:"lists"`
+	Cards []json.RawMessage `json:"cards"`
+}
+
+type trelloCard struct {
+	Name        string  `json:"name"`
+	Desc        string  `json:"desc"`
+	IDList      string  `json:"idList"`
+	Due         *string `json:"due"`
+	DueComplete bool    `json:"dueComplete"`
+	Closed      bool    `json:"closed"`
+	Labels      []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// parseTrello reads a Trello board export. Each card becomes a todo filed
+// under the name of its list; archived cards and lists are left out. A label
+// named high, medium or low sets the priority, which is medium otherwise.
+func parseTrello(r io.Reader) (res []Row, err error) {
+	var board trelloBoard
+	if err = json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, err
+	}
+
+	lists := make(map[string]string, len(board.Lists))
+	closed := map[string]bool{}
+	for _, l := range board.Lists {
+		lists[l.ID] = l.Name
+		closed[l.ID] = l.Closed
+	}
+
+	for i, raw := range board.Cards {
+		row := Row{Number: i + 1}
+
+		var card trelloCard
+		if err := json.Unmarshal(raw, &card); err != nil {
+			row.Err = err
+			res = append(res, row)
+			continue
+		}
+		if card.Closed || closed[card.IDList] {
+			continue
+		}
+
+		category := lists[card.IDList]
+		if category == "" {
+			category = board.Name
+		}
+
+		row.Todo, row.Err = trelloTodo(card, category)
+		res = append(res, row)
+	}
+
+	return
+}
+
+func trelloTodo(card trelloCard, category string) (td domain.Todo, err error) {
+	td = domain.Todo{
+		Text:          card.Name,
+		Notes:         card.Desc,
+		Category:      category,
+		PriorityLevel: domain.Medium,
+		Completed:     card.DueComplete,
+	}
+
+	for _, label := range card.Labels {
+		if level, ok := domain.ParsePriority(label.Name); ok {
+			td.PriorityLevel = level
+		}
+	}
+
+	if card.Due != nil {
+		date, err := parseTime(*card.Due)
+		if err != nil {
+			return domain.Todo{}, fmt.Errorf("due: %w", err)
+		}
+		td.Date = &date
+	}
+
+	return
+}
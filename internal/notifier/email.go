@@ -0,0 +1,73 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type EmailDirectory interface {
+	GetSettings(ctx context.Context, id int64) (domain.UserSettings, error)
+}
+
+// Email sends notifications over SMTP to the address in the user's settings.
+// Users without an address are skipped.
+type Email struct {
+	Addr      string
+	From      string
+	Auth      smtp.Auth
+	Directory EmailDirectory
+}
+
+// NewEmail returns an Email notifier for the SMTP server at host:port. Auth
+// is only used when a username is given.
+func NewEmail(host string, port string, username string, password string, from string, directory EmailDirectory) *Email {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &Email{
+		Addr:      net.JoinHostPort(host, port),
+		From:      from,
+		Auth:      auth,
+		Directory: directory,
+	}
+}
+
+func (e *Email) Notify(ctx context.Context, n domain.Notification) error {
+	settings, err := e.Directory.GetSettings(ctx, n.UserID)
+	if err != nil {
+		return err
+	}
+	if settings.Email == nil || *settings.Email == "" {
+		return nil
+	}
+
+	return smtp.SendMail(e.Addr, e.Auth, e.From, []string{*settings.Email}, e.message(*settings.Email, n))
+}
+
+func (e *Email) message(to string, n domain.Notification) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", e.From)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", sanitizeHeader(n.Title))
+	fmt.Fprintf(&b, "Date: %s\r\n", n.CreatedAt.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(n.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	return []byte(b.String())
+}
+
+// sanitizeHeader keeps user text such as a todo title from injecting headers.
+func sanitizeHeader(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
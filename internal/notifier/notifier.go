@@ -0,0 +1,27 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// Notifier delivers a notification over one channel.
+type Notifier interface {
+	Notify(ctx context.Context, n domain.Notification) error
+}
+
+// Log writes notifications to the application log. It is the default when
+// no other channel is configured.
+type Log struct{}
+
+func (Log) Notify(ctx context.Context, n domain.Notification) error {
+	logrus.WithFields(logrus.Fields{
+		"user_id": n.UserID,
+		"kind":    n.Kind,
+		"todo_id": n.TodoID,
+	}).Info(n.Title)
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	notifyChannel = "todo_stream"
+	// maxPayload stays below the 8000 byte limit Postgres puts on a
+	// notification payload.
+	maxPayload = 7900
+	// reconnectDelay is how long Listen waits before reconnecting after the
+	// listening connection failed.
+	reconnectDelay = 5 * time.Second
+)
+
+type notification struct {
+	UserIDs []int64            `json:"user_ids"`
+	Event   domain.StreamEvent `json:"event"`
+}
+
+// PostgresBroker publishes events with NOTIFY and delivers the events it
+// LISTENs to to the subscribers of this instance. Listen must be running for
+// subscribers to receive anything.
+type PostgresBroker struct {
+	Conn *pgxpool.Pool
+	hub  *Hub
+}
+
+func NewPostgresBroker(conn *pgxpool.Pool) *PostgresBroker {
+	return &PostgresBroker{
+		Conn: conn,
+		hub:  NewHub(),
+	}
+}
+
+func (p *PostgresBroker) Publish(ctx context.Context, userIDs []int64, ev domain.StreamEvent) error {
+	payload, err := json.Marshal(notification{UserIDs: userIDs, Event: ev})
+	if err != nil {
+		return err
+	}
+
+	// Clients fetch the todo themselves when it does not fit.
+	if len(payload) > maxPayload {
+		ev.Todo = nil
+		payload, err = json.Marshal(notification{UserIDs: userIDs, Event: ev})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = p.Conn.Exec(ctx, `SELECT pg_notify($1, $2)`, notifyChannel, string(payload))
+	return err
+}
+
+func (p *PostgresBroker) Subscribe(userID int64) (<-chan domain.StreamEvent, func()) {
+	return p.hub.Subscribe(userID)
+}
+
+// Listen relays notifications to local subscribers until ctx is cancelled,
+// reconnecting whenever the listening connection is lost.
+func (p *PostgresBroker) Listen(ctx context.Context) {
+	for {
+		err := p.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		logrus.WithField("worker", "event-listener").Error(err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// listen uses a dedicated connection so the LISTEN does not linger on a
+// pooled connection after it is given back.
+func (p *PostgresBroker) listen(ctx context.Context) error {
+	conn, err := pgx.ConnectConfig(ctx, p.Conn.Config().ConnConfig)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN "+notifyChannel)
+	if err != nil {
+		return err
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var msg notification
+		if err = json.Unmarshal([]byte(n.Payload), &msg); err != nil {
+			logrus.Error(err)
+			continue
+		}
+
+		p.hub.deliver(msg.UserIDs, msg.Event)
+	}
+}
@@ -0,0 +1,79 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// Broker fans todo events out to the subscribers of the users they concern.
+// Hub does so within a single process; PostgresBroker relays events through
+// LISTEN/NOTIFY so every instance of the API receives them.
+type Broker interface {
+	Publish(ctx context.Context, userIDs []int64, ev domain.StreamEvent) error
+	Subscribe(userID int64) (events <-chan domain.StreamEvent, unsubscribe func())
+}
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// further events are dropped for it.
+const subscriberBuffer = 32
+
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[int64]map[chan domain.StreamEvent]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: map[int64]map[chan domain.StreamEvent]struct{}{},
+	}
+}
+
+func (h *Hub) Publish(ctx context.Context, userIDs []int64, ev domain.StreamEvent) error {
+	h.deliver(userIDs, ev)
+	return nil
+}
+
+func (h *Hub) deliver(userIDs []int64, ev domain.StreamEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, userID := range userIDs {
+		for ch := range h.subscribers[userID] {
+			select {
+			case ch <- ev:
+			default:
+				logrus.Warnf("dropping %s event of todo %d for a slow subscriber of user %d", ev.Type, ev.TodoID, userID)
+			}
+		}
+	}
+}
+
+func (h *Hub) Subscribe(userID int64) (<-chan domain.StreamEvent, func()) {
+	ch := make(chan domain.StreamEvent, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = map[chan domain.StreamEvent]struct{}{}
+	}
+	h.subscribers[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			delete(h.subscribers[userID], ch)
+			if len(h.subscribers[userID]) == 0 {
+				delete(h.subscribers, userID)
+			}
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
@@ -0,0 +1,425 @@
+// Package quickadd parses one line of free text such as
+// "buy milk tomorrow 5pm #errands !high" into the parts of a todo.
+package quickadd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+// Result holds what was recognised in the input. Text is the input without
+// the recognised words. Due is zero when no date or time was given; HasTime
+// tells whether it carries a time of day or only a date.
+type Result struct {
+	Text     string
+	Category string
+	Priority domain.PriorityLevel
+	Due      time.Time
+	HasTime  bool
+}
+
+// parser keeps the state of one Parse call. The first date, time, category
+// and priority found win; later ones are left in the text.
+type parser struct {
+	now    time.Time
+	tokens []string
+	words  []string
+
+	date    *time.Time
+	clock   *[2]int
+	instant *time.Time
+
+	category string
+	priority domain.PriorityLevel
+}
+
+// Parse reads the input relative to now, whose location is the user's time
+// zone: "tomorrow" is the day after now in that zone.
+func Parse(input string, now time.Time) Result {
+	p := &parser{now: now, tokens: strings.Fields(input)}
+
+	for i := 0; i < len(p.tokens); {
+		n := p.match(i)
+		if n == 0 {
+			p.words = append(p.words, p.tokens[i])
+			n = 1
+		}
+		i += n
+	}
+
+	return p.result()
+}
+
+// match tries every rule at token i and returns the number of tokens used.
+func (p *parser) match(i int) int {
+	token := p.tokens[i]
+
+	if p.category == "" && len(token) > 1 && token[0] == '#' {
+		p.category = strings.TrimRight(token[1:], ",.")
+		return 1
+	}
+	if p.priority == "" && len(token) > 1 && token[0] == '!' {
+		if priority, ok := parsePriority(token[1:]); ok {
+			p.priority = priority
+			return 1
+		}
+	}
+
+	if p.date == nil && p.instant == nil {
+		if n := p.matchDate(i); n > 0 {
+			return n
+		}
+		// "on friday", "by tomorrow", "due next week"
+		if prepositions[p.word(i)] {
+			if n := p.matchDate(i + 1); n > 0 {
+				return n + 1
+			}
+		}
+	}
+
+	if p.clock == nil && p.instant == nil {
+		if n := p.matchTime(i); n > 0 {
+			return n
+		}
+		if p.word(i) == "at" {
+			if n := p.matchTime(i + 1); n > 0 {
+				return n + 1
+			}
+			// "at 17" has no am/pm but is still a time after "at".
+			if hour, err := strconv.Atoi(p.word(i + 1)); err == nil && hour >= 0 && hour <= 23 {
+				p.clock = &[2]int{hour, 0}
+				return 2
+			}
+		}
+	}
+
+	return 0
+}
+
+// word returns token i in lower case without trailing punctuation, or "" past
+// the end.
+func (p *parser) word(i int) string {
+	if i >= len(p.tokens) {
+		return ""
+	}
+
+	return strings.ToLower(strings.TrimRight(p.tokens[i], ",.;"))
+}
+
+func (p *parser) today() time.Time {
+	y, m, d := p.now.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, p.now.Location())
+}
+
+func (p *parser) setDate(t time.Time) {
+	p.date = &t
+}
+
+func (p *parser) matchDate(i int) int {
+	word := p.word(i)
+	today := p.today()
+
+	switch word {
+	case "today":
+		p.setDate(today)
+		return 1
+	case "tonight":
+		p.setDate(today)
+		if p.clock == nil {
+			p.clock = &[2]int{20, 0}
+		}
+		return 1
+	case "tomorrow", "tmr", "tmrw":
+		p.setDate(today.AddDate(0, 0, 1))
+		return 1
+	case "next":
+		switch next := p.word(i + 1); next {
+		case "week":
+			p.setDate(nextWeekday(today, time.Monday))
+			return 2
+		case "month":
+			p.setDate(today.AddDate(0, 1, 0))
+			return 2
+		case "year":
+			p.setDate(today.AddDate(1, 0, 0))
+			return 2
+		default:
+			if weekday, ok := weekdays[next]; ok {
+				p.setDate(nextWeekday(today, weekday))
+				return 2
+			}
+		}
+	case "in":
+		return p.matchDuration(i + 1)
+	}
+
+	if weekday, ok := weekdays[word]; ok {
+		p.setDate(nextWeekday(today, weekday))
+		return 1
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", word, p.now.Location()); err == nil {
+		p.setDate(t)
+		return 1
+	}
+
+	// "jan 5", "january 5th", "5 jan", optionally followed by a year.
+	month, monthOK := months[word]
+	day, dayOK := parseDay(p.word(i + 1))
+	if !monthOK || !dayOK {
+		day, dayOK = parseDay(word)
+		month, monthOK = months[p.word(i+1)]
+	}
+	if monthOK && dayOK {
+		if year, err := strconv.Atoi(p.word(i + 2)); err == nil && year >= 1000 && year <= 9999 {
+			p.setDate(time.Date(year, month, day, 0, 0, 0, 0, p.now.Location()))
+			return 3
+		}
+
+		t := time.Date(today.Year(), month, day, 0, 0, 0, 0, p.now.Location())
+		if t.Before(today) {
+			t = t.AddDate(1, 0, 0)
+		}
+		p.setDate(t)
+		return 2
+	}
+
+	return 0
+}
+
+// matchDuration reads the "3 days" of "in 3 days". Hours and minutes are
+// counted from now; longer units keep the time of day open.
+func (p *parser) matchDuration(i int) int {
+	amount, err := strconv.Atoi(p.word(i))
+	if err != nil || amount < 0 {
+		if p.word(i) != "a" && p.word(i) != "an" {
+			return 0
+		}
+		amount = 1
+	}
+
+	switch p.word(i + 1) {
+	case "minute", "minutes", "min", "mins":
+		t := p.now.Add(time.Duration(amount) * time.Minute)
+		p.instant = &t
+	case "hour", "hours", "h", "hr", "hrs":
+		t := p.now.Add(time.Duration(amount) * time.Hour)
+		p.instant = &t
+	case "day", "days", "d":
+		p.setDate(p.today().AddDate(0, 0, amount))
+	case "week", "weeks", "w", "wk", "wks":
+		p.setDate(p.today().AddDate(0, 0, 7*amount))
+	case "month", "months":
+		p.setDate(p.today().AddDate(0, amount, 0))
+	case "year", "years":
+		p.setDate(p.today().AddDate(amount, 0, 0))
+	default:
+		return 0
+	}
+
+	// "in" plus the amount and the unit.
+	return 3
+}
+
+func (p *parser) matchTime(i int) int {
+	word := p.word(i)
+
+	if clock, ok := namedTimes[word]; ok {
+		p.clock = &clock
+		return 1
+	}
+
+	// "5pm", "5:30am"
+	for _, suffix := range []string{"am", "pm"} {
+		if strings.HasSuffix(word, suffix) {
+			if clock, ok := parseClock(strings.TrimSuffix(word, suffix), suffix); ok {
+				p.clock = &clock
+				return 1
+			}
+		}
+	}
+
+	// "5 pm"
+	if suffix := p.word(i + 1); suffix == "am" || suffix == "pm" {
+		if clock, ok := parseClock(word, suffix); ok {
+			p.clock = &clock
+			return 2
+		}
+	}
+
+	// "17:00"
+	if strings.Contains(word, ":") {
+		if clock, ok := parseClock(word, ""); ok {
+			p.clock = &clock
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func (p *parser) result() Result {
+	res := Result{
+		Text:     strings.Join(p.words, " "),
+		Category: p.category,
+		Priority: p.priority,
+	}
+
+	switch {
+	case p.instant != nil:
+		res.Due = *p.instant
+		res.HasTime = true
+	case p.clock != nil:
+		date := p.today()
+		if p.date != nil {
+			date = *p.date
+		}
+		res.Due = time.Date(date.Year(), date.Month(), date.Day(), p.clock[0], p.clock[1], 0, 0, p.now.Location())
+		res.HasTime = true
+		// A time alone means its next occurrence.
+		if p.date == nil && res.Due.Before(p.now) {
+			res.Due = res.Due.AddDate(0, 0, 1)
+		}
+	case p.date != nil:
+		res.Due = *p.date
+	}
+
+	return res
+}
+
+// nextWeekday returns the next given weekday after today; a week from today
+// when today is that weekday.
+func nextWeekday(today time.Time, weekday time.Weekday) time.Time {
+	days := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+
+	return today.AddDate(0, 0, days)
+}
+
+// parseDay reads a day of the month such as "5" or "5th".
+func parseDay(word string) (int, bool) {
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		word = strings.TrimSuffix(word, suffix)
+	}
+
+	day, err := strconv.Atoi(word)
+	if err != nil || day < 1 || day > 31 {
+		return 0, false
+	}
+
+	return day, true
+}
+
+// parseClock reads "5", "5:30" or "17:00". With an am/pm suffix the hour
+// must be on the 12-hour clock.
+func parseClock(s string, suffix string) (clock [2]int, ok bool) {
+	hour, minute := s, "0"
+	if h, m, found := strings.Cut(s, ":"); found {
+		hour, minute = h, m
+	}
+
+	h, err := strconv.Atoi(hour)
+	if err != nil {
+		return clock, false
+	}
+	m, err := strconv.Atoi(minute)
+	if err != nil || m < 0 || m > 59 {
+		return clock, false
+	}
+
+	switch suffix {
+	case "am", "pm":
+		if h < 1 || h > 12 {
+			return clock, false
+		}
+		h %= 12
+		if suffix == "pm" {
+			h += 12
+		}
+	default:
+		if h < 0 || h > 23 {
+			return clock, false
+		}
+	}
+
+	return [2]int{h, m}, true
+}
+
+// parsePriority reads what domain.ParsePriority does, such as "high", "p1"
+// or "1", and the shorthands in priorityAliases.
+func parsePriority(word string) (domain.PriorityLevel, bool) {
+	if level, ok := priorityAliases[strings.ToLower(word)]; ok {
+		return level, true
+	}
+
+	return domain.ParsePriority(word)
+}
+
+var priorityAliases = map[string]domain.PriorityLevel{
+	"u":   domain.Urgent,
+	"h":   domain.High,
+	"med": domain.Medium,
+	"m":   domain.Medium,
+	"l":   domain.Low,
+}
+
+var prepositions = map[string]bool{"on": true, "by": true, "due": true}
+
+var namedTimes = map[string][2]int{
+	"morning":   {9, 0},
+	"noon":      {12, 0},
+	"midday":    {12, 0},
+	"afternoon": {15, 0},
+	"evening":   {18, 0},
+	"midnight":  {23, 59},
+}
+
+// Abbreviations that are also common words, like "sun" or "sat", are left
+// out so they stay in the text.
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"mon":       time.Monday,
+	"tuesday":   time.Tuesday,
+	"tue":       time.Tuesday,
+	"tues":      time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"thu":       time.Thursday,
+	"thurs":     time.Thursday,
+	"friday":    time.Friday,
+	"fri":       time.Friday,
+	"saturday":  time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"january":   time.January,
+	"jan":       time.January,
+	"february":  time.February,
+	"feb":       time.February,
+	"march":     time.March,
+	"mar":       time.March,
+	"april":     time.April,
+	"apr":       time.April,
+	"may":       time.May,
+	"june":      time.June,
+	"jun":       time.June,
+	"july":      time.July,
+	"jul":       time.July,
+	"august":    time.August,
+	"aug":       time.August,
+	"september": time.September,
+	"sep":       time.September,
+	"sept":      time.September,
+	"october":   time.October,
+	"oct":       time.October,
+	"november":  time.November,
+	"nov":       time.November,
+	"december":  time.December,
+	"dec":       time.December,
+}
@@ -0,0 +1,110 @@
+package quickadd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	loc := time.FixedZone("UTC+7", 7*60*60)
+	at := func(year int, month time.Month, day int, hour int, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, loc)
+	}
+	date := func(year int, month time.Month, day int) time.Time {
+		return at(year, month, day, 0, 0)
+	}
+
+	// A Wednesday morning.
+	wednesday := at(2024, time.March, 13, 10, 30)
+	lateEvening := at(2024, time.March, 13, 23, 50)
+	newYearsEve := at(2024, time.December, 31, 22, 0)
+
+	tests := []struct {
+		name  string
+		input string
+		now   time.Time
+		want  Result
+	}{
+		{"plain text", "buy milk", wednesday, Result{Text: "buy milk"}},
+		{"today", "buy milk today", wednesday, Result{Text: "buy milk", Due: date(2024, time.March, 13)}},
+		{"tomorrow", "buy milk tomorrow", wednesday, Result{Text: "buy milk", Due: date(2024, time.March, 14)}},
+		{"tmrw", "call mom tmrw", wednesday, Result{Text: "call mom", Due: date(2024, time.March, 14)}},
+		{"tonight", "read tonight", wednesday, Result{Text: "read", Due: at(2024, time.March, 13, 20, 0), HasTime: true}},
+		{"next week", "plan next week", wednesday, Result{Text: "plan", Due: date(2024, time.March, 18)}},
+		{"next month", "rent next month", wednesday, Result{Text: "rent", Due: date(2024, time.April, 13)}},
+
+		{"weekday later this week", "demo friday", wednesday, Result{Text: "demo", Due: date(2024, time.March, 15)}},
+		{"weekday abbreviation", "demo fri", wednesday, Result{Text: "demo", Due: date(2024, time.March, 15)}},
+		{"same weekday is a week out", "standup wednesday", wednesday, Result{Text: "standup", Due: date(2024, time.March, 20)}},
+		{"weekday next week", "standup monday", wednesday, Result{Text: "standup", Due: date(2024, time.March, 18)}},
+		{"next weekday", "review next tue", wednesday, Result{Text: "review", Due: date(2024, time.March, 19)}},
+		{"preposition", "taxes due friday", wednesday, Result{Text: "taxes", Due: date(2024, time.March, 15)}},
+		{"common word stays", "sat on the mat", wednesday, Result{Text: "sat on the mat"}},
+
+		{"in days", "follow up in 3 days", wednesday, Result{Text: "follow up", Due: date(2024, time.March, 16)}},
+		{"in a week", "follow up in a week", wednesday, Result{Text: "follow up", Due: date(2024, time.March, 20)}},
+		{"in weeks", "follow up in 2 wks", wednesday, Result{Text: "follow up", Due: date(2024, time.March, 27)}},
+		{"in months", "renew in 2 months", wednesday, Result{Text: "renew", Due: date(2024, time.May, 13)}},
+		{"in hours", "check oven in 2 hours", wednesday, Result{Text: "check oven", Due: at(2024, time.March, 13, 12, 30), HasTime: true}},
+		{"in minutes", "tea in 15 min", wednesday, Result{Text: "tea", Due: at(2024, time.March, 13, 10, 45), HasTime: true}},
+		{"in without unit", "log in 3", wednesday, Result{Text: "log in 3"}},
+
+		{"month day", "party march 20", wednesday, Result{Text: "party", Due: date(2024, time.March, 20)}},
+		{"day month", "party 20th mar", wednesday, Result{Text: "party", Due: date(2024, time.March, 20)}},
+		{"past month day is next year", "party jan 5", wednesday, Result{Text: "party", Due: date(2025, time.January, 5)}},
+		{"earlier this month is next year", "party 5th march", wednesday, Result{Text: "party", Due: date(2025, time.March, 5)}},
+		{"month day year", "party dec 31 2025", wednesday, Result{Text: "party", Due: date(2025, time.December, 31)}},
+		{"iso date", "party 2024-04-01", wednesday, Result{Text: "party", Due: date(2024, time.April, 1)}},
+
+		{"pm", "call 5pm", wednesday, Result{Text: "call", Due: at(2024, time.March, 13, 17, 0), HasTime: true}},
+		{"am with minutes", "call 11:15am", wednesday, Result{Text: "call", Due: at(2024, time.March, 13, 11, 15), HasTime: true}},
+		{"separate pm", "call 5 pm", wednesday, Result{Text: "call", Due: at(2024, time.March, 13, 17, 0), HasTime: true}},
+		{"12am is midnight", "backup tomorrow 12am", wednesday, Result{Text: "backup", Due: at(2024, time.March, 14, 0, 0), HasTime: true}},
+		{"12pm is noon", "lunch 12pm", wednesday, Result{Text: "lunch", Due: at(2024, time.March, 13, 12, 0), HasTime: true}},
+		{"24 hour clock", "call 17:00", wednesday, Result{Text: "call", Due: at(2024, time.March, 13, 17, 0), HasTime: true}},
+		{"at hour", "call at 17", wednesday, Result{Text: "call", Due: at(2024, time.March, 13, 17, 0), HasTime: true}},
+		{"at hour out of range", "gate at 25", wednesday, Result{Text: "gate at 25"}},
+		{"time before date", "call 5pm tomorrow", wednesday, Result{Text: "call", Due: at(2024, time.March, 14, 17, 0), HasTime: true}},
+		{"date before time", "call friday at 9am", wednesday, Result{Text: "call", Due: at(2024, time.March, 15, 9, 0), HasTime: true}},
+		{"noon", "lunch noon", wednesday, Result{Text: "lunch", Due: at(2024, time.March, 13, 12, 0), HasTime: true}},
+		{"evening", "gym evening", wednesday, Result{Text: "gym", Due: at(2024, time.March, 13, 18, 0), HasTime: true}},
+		{"midnight", "submit midnight", wednesday, Result{Text: "submit", Due: at(2024, time.March, 13, 23, 59), HasTime: true}},
+
+		{"passed time is tomorrow", "run 9am", wednesday, Result{Text: "run", Due: at(2024, time.March, 14, 9, 0), HasTime: true}},
+		{"passed named time is tomorrow", "run morning", wednesday, Result{Text: "run", Due: at(2024, time.March, 14, 9, 0), HasTime: true}},
+		{"passed time today stays today", "run today 9am", wednesday, Result{Text: "run", Due: at(2024, time.March, 13, 9, 0), HasTime: true}},
+		{"time rolls past midnight", "sleep at 9pm", lateEvening, Result{Text: "sleep", Due: at(2024, time.March, 14, 21, 0), HasTime: true}},
+		{"minutes roll past midnight", "lights off in 20 minutes", lateEvening, Result{Text: "lights off", Due: at(2024, time.March, 14, 0, 10), HasTime: true}},
+
+		{"tomorrow at year end", "brunch tomorrow", newYearsEve, Result{Text: "brunch", Due: date(2025, time.January, 1)}},
+		{"time rolls into the new year", "brunch 8pm", newYearsEve, Result{Text: "brunch", Due: at(2025, time.January, 1, 20, 0), HasTime: true}},
+		{"new year's day", "brunch jan 1", newYearsEve, Result{Text: "brunch", Due: date(2025, time.January, 1)}},
+		{"today's date is this year", "fireworks dec 31", newYearsEve, Result{Text: "fireworks", Due: date(2024, time.December, 31)}},
+		{"earlier date is next year", "cleanup dec 30", newYearsEve, Result{Text: "cleanup", Due: date(2025, time.December, 30)}},
+		{"weekday into the new year", "gym friday", newYearsEve, Result{Text: "gym", Due: date(2025, time.January, 3)}},
+
+		{"category", "buy milk #errands", wednesday, Result{Text: "buy milk", Category: "errands"}},
+		{"category with punctuation", "buy milk #errands, soon", wednesday, Result{Text: "buy milk soon", Category: "errands"}},
+		{"bare hash stays", "issue # 5", wednesday, Result{Text: "issue # 5"}},
+		{"priority", "fix bug !high", wednesday, Result{Text: "fix bug", Priority: "high"}},
+		{"priority shorthand", "fix bug !p1", wednesday, Result{Text: "fix bug", Priority: "high"}},
+		{"priority upper case shorthand", "fix bug !P0", wednesday, Result{Text: "fix bug", Priority: "urgent"}},
+		{"priority rank", "fix bug !4", wednesday, Result{Text: "fix bug", Priority: "lowest"}},
+		{"priority alias", "fix bug !med", wednesday, Result{Text: "fix bug", Priority: "medium"}},
+		{"priority out of range stays", "fix bug !p5", wednesday, Result{Text: "fix bug !p5"}},
+		{"priority case", "fix bug !URGENT", wednesday, Result{Text: "fix bug", Priority: "urgent"}},
+		{"unknown priority stays", "wow !nice", wednesday, Result{Text: "wow !nice"}},
+		{"first of each wins", "pay today tomorrow #a #b !low !high", wednesday, Result{Text: "pay tomorrow #b !high", Category: "a", Priority: "low", Due: date(2024, time.March, 13)}},
+		{"everything", "buy milk tomorrow 5pm #errands !high", wednesday, Result{Text: "buy milk", Category: "errands", Priority: "high", Due: at(2024, time.March, 14, 17, 0), HasTime: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.input, tt.now)
+
+			if got.Text != tt.want.Text || got.Category != tt.want.Category || got.Priority != tt.want.Priority || got.HasTime != tt.want.HasTime || !got.Due.Equal(tt.want.Due) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
 	"time"
 )
 
 const (
-	timeFormat = "2006-01-02T15:04:05.999Z07:00"
+	timeFormat = "2006-01-02T15:04:05.999999Z07:00"
 )
 
 func DecodeCursor(encodedTime string) (time.Time, error) {
@@ -25,4 +28,37 @@ func EncodeCursor(t time.Time) string {
 	timeString := t.Format(timeFormat)
 
 	return base64.StdEncoding.EncodeToString([]byte(timeString))
-}
\ No newline at end of file
+}
+
+// EncodeKeyCursor encodes the created_at and id of the last row of a page.
+// Paging on both keeps rows that share a timestamp, such as the ones written
+// in one transaction, from being skipped or repeated.
+func EncodeKeyCursor(t time.Time, id int64) string {
+	key := t.Format(timeFormat) + "," + strconv.FormatInt(id, 10)
+
+	return base64.StdEncoding.EncodeToString([]byte(key))
+}
+
+func DecodeKeyCursor(encoded string) (time.Time, int64, error) {
+	byt, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	timeString, idString, ok := strings.Cut(string(byt), ",")
+	if !ok {
+		return time.Time{}, 0, errors.New("invalid cursor")
+	}
+
+	t, err := time.Parse(timeFormat, timeString)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	id, err := strconv.ParseInt(idString, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	return t, id, nil
+}
@@ -0,0 +1,117 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AttachmentRepository struct {
+	Conn *pgxpool.Pool
+}
+
+func NewAttachmentRepository(conn *pgxpool.Pool) *AttachmentRepository {
+	return &AttachmentRepository{
+		Conn: conn,
+	}
+}
+
+func (a *AttachmentRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Attachment, err error) {
+	rows, err := db(ctx, a.Conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		at := domain.Attachment{}
+		err = rows.Scan(
+			&at.ID,
+			&at.TodoID,
+			&at.UserID,
+			&at.Filename,
+			&at.ContentType,
+			&at.Size,
+			&at.StorageKey,
+			&at.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, at)
+	}
+
+	return
+}
+
+func (a *AttachmentRepository) GetByTodoID(ctx context.Context, todoID int64) (res []domain.Attachment, err error) {
+	query := `SELECT id, todo_id, user_id, filename, content_type, size, storage_key, created_at FROM todo_attachments WHERE todo_id = $1 ORDER BY created_at`
+
+	res, err = a.fetch(ctx, query, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (a *AttachmentRepository) GetByID(ctx context.Context, id int64) (res domain.Attachment, err error) {
+	query := `SELECT id, todo_id, user_id, filename, content_type, size, storage_key, created_at FROM todo_attachments WHERE id = $1`
+
+	list, err := a.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+// GetOrphans returns attachments whose todo has been permanently deleted.
+func (a *AttachmentRepository) GetOrphans(ctx context.Context, limit int64) (res []domain.Attachment, err error) {
+	query := `SELECT id, todo_id, user_id, filename, content_type, size, storage_key, created_at FROM todo_attachments WHERE todo_id IS NULL ORDER BY id LIMIT $1`
+
+	res, err = a.fetch(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (a *AttachmentRepository) Store(ctx context.Context, at *domain.Attachment) (err error) {
+	query := `INSERT INTO todo_attachments (todo_id, user_id, filename, content_type, size, storage_key, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) returning id`
+
+	err = db(ctx, a.Conn).QueryRow(ctx, query, at.TodoID, at.UserID, at.Filename, at.ContentType, at.Size, at.StorageKey, at.CreatedAt).Scan(&at.ID)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (a *AttachmentRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := `DELETE FROM todo_attachments WHERE id = $1`
+
+	commandTag, err := db(ctx, a.Conn).Exec(ctx, query, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,172 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/abrahammegantoro/to-do-list-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CommentRepository struct {
+	Conn *pgxpool.Pool
+}
+
+func NewCommentRepository(conn *pgxpool.Pool) *CommentRepository {
+	return &CommentRepository{
+		Conn: conn,
+	}
+}
+
+func (cr *CommentRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Comment, err error) {
+	rows, err := db(ctx, cr.Conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		c := domain.Comment{}
+		err = rows.Scan(
+			&c.ID,
+			&c.TodoID,
+			&c.UserID,
+			&c.Body,
+			&c.UpdatedAt,
+			&c.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, c)
+	}
+
+	return
+}
+
+// GetByTodoID returns the comments of a todo oldest first, starting after
+// the cursor, together with the cursor of the next page.
+func (cr *CommentRepository) GetByTodoID(ctx context.Context, todoID int64, cursor string, num int64) (res []domain.Comment, nextCursor string, err error) {
+	query := `SELECT id, todo_id, user_id, body, updated_at, created_at FROM todo_comments WHERE todo_id = $1 AND (created_at, id) > ($2, $3) ORDER BY created_at, id LIMIT $4`
+
+	var createdAt time.Time
+	var id int64
+	if cursor != "" {
+		createdAt, id, err = repository.DecodeKeyCursor(cursor)
+		if err != nil {
+			return nil, "", domain.ErrBadParamInput
+		}
+	}
+
+	res, err = cr.fetch(ctx, query, todoID, createdAt, id, num)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(res) == int(num) {
+		last := res[len(res)-1]
+		nextCursor = repository.EncodeKeyCursor(last.CreatedAt, last.ID)
+	}
+
+	return
+}
+
+func (cr *CommentRepository) GetByID(ctx context.Context, id int64) (res domain.Comment, err error) {
+	query := `SELECT id, todo_id, user_id, body, updated_at, created_at FROM todo_comments WHERE id = $1`
+
+	list, err := cr.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (cr *CommentRepository) Store(ctx context.Context, c *domain.Comment) (err error) {
+	query := `INSERT INTO todo_comments (todo_id, user_id, body, updated_at, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+
+	return db(ctx, cr.Conn).QueryRow(ctx, query, c.TodoID, c.UserID, c.Body, c.UpdatedAt, c.CreatedAt).Scan(&c.ID)
+}
+
+func (cr *CommentRepository) Update(ctx context.Context, c *domain.Comment) (err error) {
+	query := `UPDATE todo_comments SET body = $1, updated_at = $2 WHERE id = $3`
+
+	commandTag, err := db(ctx, cr.Conn).Exec(ctx, query, c.Body, c.UpdatedAt, c.ID)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (cr *CommentRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := `DELETE FROM todo_comments WHERE id = $1`
+
+	commandTag, err := db(ctx, cr.Conn).Exec(ctx, query, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (cr *CommentRepository) GetMentions(ctx context.Context, commentIDs []int64) (res []domain.Mention, err error) {
+	query := `SELECT m.comment_id, m.user_id, u.username FROM comment_mentions m JOIN users u ON u.id = m.user_id WHERE m.comment_id = ANY($1) ORDER BY u.username`
+
+	rows, err := db(ctx, cr.Conn).Query(ctx, query, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		m := domain.Mention{}
+		err = rows.Scan(&m.CommentID, &m.UserID, &m.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, m)
+	}
+
+	return
+}
+
+// ReplaceMentions makes userIDs the mentioned users of the comment. Call it
+// within a transaction.
+func (cr *CommentRepository) ReplaceMentions(ctx context.Context, commentID int64, userIDs []int64) (err error) {
+	query := `DELETE FROM comment_mentions WHERE comment_id = $1`
+
+	_, err = db(ctx, cr.Conn).Exec(ctx, query, commentID)
+	if err != nil {
+		return
+	}
+
+	query = `INSERT INTO comment_mentions (comment_id, user_id) SELECT $1, unnest($2::BIGINT[])`
+
+	_, err = db(ctx, cr.Conn).Exec(ctx, query, commentID, userIDs)
+	return
+}
@@ -0,0 +1,101 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/abrahammegantoro/to-do-list-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TodoEventRepository struct {
+	Conn *pgxpool.Pool
+}
+
+func NewTodoEventRepository(conn *pgxpool.Pool) *TodoEventRepository {
+	return &TodoEventRepository{
+		Conn: conn,
+	}
+}
+
+func (e *TodoEventRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.TodoEvent, err error) {
+	rows, err := db(ctx, e.Conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		ev := domain.TodoEvent{}
+		err = rows.Scan(
+			&ev.ID,
+			&ev.TodoID,
+			&ev.ActorID,
+			&ev.Type,
+			&ev.Changes,
+			&ev.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, ev)
+	}
+
+	return
+}
+
+// fetchPage runs a newest-first query whose last three parameters are the
+// created_at and id of the cursor and the page size, and returns the cursor
+// of the next page.
+func (e *TodoEventRepository) fetchPage(ctx context.Context, query string, cursor string, num int64, args ...interface{}) (res []domain.TodoEvent, nextCursor string, err error) {
+	var before *time.Time
+	var beforeID *int64
+	if cursor != "" {
+		createdAt, id, err := repository.DecodeKeyCursor(cursor)
+		if err != nil {
+			return nil, "", domain.ErrBadParamInput
+		}
+		before, beforeID = &createdAt, &id
+	}
+
+	res, err = e.fetch(ctx, query, append(args, before, beforeID, num)...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(res) == int(num) {
+		last := res[len(res)-1]
+		nextCursor = repository.EncodeKeyCursor(last.CreatedAt, last.ID)
+	}
+
+	return
+}
+
+func (e *TodoEventRepository) GetByTodoID(ctx context.Context, todoID int64, cursor string, num int64) (res []domain.TodoEvent, nextCursor string, err error) {
+	query := `SELECT id, todo_id, actor_id, type, changes, created_at FROM todo_events
+		WHERE todo_id = $1 AND ($2::TIMESTAMPTZ IS NULL OR (created_at, id) < ($2, $3::BIGINT))
+		ORDER BY created_at DESC, id DESC LIMIT $4`
+
+	return e.fetchPage(ctx, query, cursor, num, todoID)
+}
+
+// GetFeed returns the events of every todo the user can see: their personal
+// todos and the todos on lists they are a member of.
+func (e *TodoEventRepository) GetFeed(ctx context.Context, userID int64, cursor string, num int64) (res []domain.TodoEvent, nextCursor string, err error) {
+	query := `SELECT e.id, e.todo_id, e.actor_id, e.type, e.changes, e.created_at FROM todo_events e
+		JOIN todos t ON t.id = e.todo_id
+		WHERE ((t.list_id IS NULL AND t.user_id = $1) OR t.list_id IN (SELECT list_id FROM list_members WHERE user_id = $1))
+		AND ($2::TIMESTAMPTZ IS NULL OR (e.created_at, e.id) < ($2, $3::BIGINT))
+		ORDER BY e.created_at DESC, e.id DESC LIMIT $4`
+
+	return e.fetchPage(ctx, query, cursor, num, userID)
+}
+
+func (e *TodoEventRepository) Store(ctx context.Context, ev *domain.TodoEvent) (err error) {
+	query := `INSERT INTO todo_events (todo_id, actor_id, type, changes, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+
+	return db(ctx, e.Conn).QueryRow(ctx, query, ev.TodoID, ev.ActorID, ev.Type, ev.Changes, ev.CreatedAt).Scan(&ev.ID)
+}
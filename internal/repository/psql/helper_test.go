@@ -0,0 +1,75 @@
+package psql
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// testContext returns a context carrying a transaction on the database in
+// TEST_DATABASE_URL, which must have the migrations applied. The transaction
+// is rolled back when the test ends. Without the variable the test is
+// skipped.
+func testContext(t *testing.T) (context.Context, *pgxpool.Pool) {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		pool.Close()
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = tx.Rollback(ctx)
+		pool.Close()
+	})
+
+	return context.WithValue(ctx, txKey{}, tx), pool
+}
+
+func insertUser(t *testing.T, ctx context.Context, pool *pgxpool.Pool, timezone string) (id int64) {
+	t.Helper()
+
+	query := `INSERT INTO users (username, password, name, timezone) VALUES ('test-' || gen_random_uuid(), '', 'Test', $1) RETURNING id`
+
+	if err := db(ctx, pool).QueryRow(ctx, query, timezone).Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+
+	return
+}
+
+func insertTodo(t *testing.T, ctx context.Context, pool *pgxpool.Pool, userID int64, assigneeID *int64, date time.Time, allDay bool) (id int64) {
+	t.Helper()
+
+	query := `INSERT INTO todos (text, category, date, all_day, priority, user_id, assignee_id) VALUES ('test', 'test', $1, $2, 2, $3, $4) RETURNING id`
+
+	if err := db(ctx, pool).QueryRow(ctx, query, date, allDay, userID, assigneeID).Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+
+	return
+}
+
+// allDay returns the date an all-day todo is stored with: midnight UTC.
+func allDay(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func utc(year int, month time.Month, day int, hour int, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
@@ -0,0 +1,232 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ListRepository struct {
+	Conn *pgxpool.Pool
+}
+
+func NewListRepository(conn *pgxpool.Pool) *ListRepository {
+	return &ListRepository{
+		Conn: conn,
+	}
+}
+
+func (l *ListRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.List, err error) {
+	rows, err := db(ctx, l.Conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		list := domain.List{}
+		err = rows.Scan(
+			&list.ID,
+			&list.Name,
+			&list.Role,
+			&list.UpdatedAt,
+			&list.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, list)
+	}
+
+	return
+}
+
+func (l *ListRepository) GetByUserID(ctx context.Context, userID int64) (res []domain.List, err error) {
+	query := `SELECT l.id, l.name, m.role, l.updated_at, l.created_at FROM lists l JOIN list_members m ON m.list_id = l.id WHERE m.user_id = $1 ORDER BY l.name`
+
+	res, err = l.fetch(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// GetByID returns the list as seen by userID, including that user's role.
+func (l *ListRepository) GetByID(ctx context.Context, id int64, userID int64) (res domain.List, err error) {
+	query := `SELECT l.id, l.name, m.role, l.updated_at, l.created_at FROM lists l JOIN list_members m ON m.list_id = l.id WHERE l.id = $1 AND m.user_id = $2`
+
+	list, err := l.fetch(ctx, query, id, userID)
+	if err != nil {
+		return domain.List{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (l *ListRepository) Store(ctx context.Context, list *domain.List) (err error) {
+	query := `INSERT INTO lists (name, updated_at, created_at) VALUES ($1, $2, $3) returning id`
+
+	err = db(ctx, l.Conn).QueryRow(ctx, query, list.Name, list.UpdatedAt, list.CreatedAt).Scan(&list.ID)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (l *ListRepository) Update(ctx context.Context, list *domain.List) (err error) {
+	query := `UPDATE lists SET name=$1, updated_at=$2 WHERE id=$3`
+
+	commandTag, err := db(ctx, l.Conn).Exec(ctx, query, list.Name, list.UpdatedAt, list.ID)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (l *ListRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := `DELETE FROM lists WHERE id = $1`
+
+	commandTag, err := db(ctx, l.Conn).Exec(ctx, query, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (l *ListRepository) GetMembers(ctx context.Context, listID int64) (res []domain.ListMember, err error) {
+	query := `SELECT m.list_id, m.user_id, u.username, u.name, m.role, m.created_at FROM list_members m JOIN users u ON u.id = m.user_id WHERE m.list_id = $1 ORDER BY m.created_at`
+
+	rows, err := db(ctx, l.Conn).Query(ctx, query, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		member := domain.ListMember{}
+		err = rows.Scan(
+			&member.ListID,
+			&member.UserID,
+			&member.Username,
+			&member.Name,
+			&member.Role,
+			&member.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, member)
+	}
+
+	return
+}
+
+func (l *ListRepository) GetMemberRole(ctx context.Context, listID int64, userID int64) (res domain.ListRole, err error) {
+	query := `SELECT role FROM list_members WHERE list_id = $1 AND user_id = $2`
+
+	err = db(ctx, l.Conn).QueryRow(ctx, query, listID, userID).Scan(&res)
+	if err == pgx.ErrNoRows {
+		return "", domain.ErrNotFound
+	}
+
+	return
+}
+
+// LockOwners locks the owner rows of a list for the rest of the transaction
+// and returns how many owners there are, so that concurrent role changes
+// cannot leave the list without an owner.
+func (l *ListRepository) LockOwners(ctx context.Context, listID int64) (res int64, err error) {
+	query := `SELECT user_id FROM list_members WHERE list_id = $1 AND role = 'owner' FOR UPDATE`
+
+	rows, err := db(ctx, l.Conn).Query(ctx, query, listID)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		res++
+	}
+
+	return res, rows.Err()
+}
+
+func (l *ListRepository) AddMember(ctx context.Context, member *domain.ListMember) (err error) {
+	query := `INSERT INTO list_members (list_id, user_id, role, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`
+
+	commandTag, err := db(ctx, l.Conn).Exec(ctx, query, member.ListID, member.UserID, member.Role, member.CreatedAt)
+	if err != nil {
+		return
+	}
+
+	if commandTag.RowsAffected() != 1 {
+		return domain.ErrConflict
+	}
+
+	return
+}
+
+func (l *ListRepository) UpdateMemberRole(ctx context.Context, listID int64, userID int64, role domain.ListRole) (err error) {
+	query := `UPDATE list_members SET role = $1 WHERE list_id = $2 AND user_id = $3`
+
+	commandTag, err := db(ctx, l.Conn).Exec(ctx, query, role, listID, userID)
+	if err != nil {
+		return
+	}
+
+	if commandTag.RowsAffected() != 1 {
+		return domain.ErrNotFound
+	}
+
+	return
+}
+
+// RemoveMember removes the user from the list and unassigns them from the
+// list's todos, which they can no longer see. Call it within a transaction.
+func (l *ListRepository) RemoveMember(ctx context.Context, listID int64, userID int64) (err error) {
+	query := `DELETE FROM list_members WHERE list_id = $1 AND user_id = $2`
+
+	commandTag, err := db(ctx, l.Conn).Exec(ctx, query, listID, userID)
+	if err != nil {
+		return
+	}
+
+	if commandTag.RowsAffected() != 1 {
+		return domain.ErrNotFound
+	}
+
+	query = `UPDATE todos SET assignee_id = NULL WHERE list_id = $1 AND assignee_id = $2`
+
+	_, err = db(ctx, l.Conn).Exec(ctx, query, listID, userID)
+	return
+}
@@ -0,0 +1,121 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/abrahammegantoro/to-do-list-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type NotificationRepository struct {
+	Conn *pgxpool.Pool
+}
+
+func NewNotificationRepository(conn *pgxpool.Pool) *NotificationRepository {
+	return &NotificationRepository{
+		Conn: conn,
+	}
+}
+
+func (n *NotificationRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Notification, err error) {
+	rows, err := db(ctx, n.Conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		nt := domain.Notification{}
+		err = rows.Scan(
+			&nt.ID,
+			&nt.UserID,
+			&nt.Kind,
+			&nt.Title,
+			&nt.Body,
+			&nt.TodoID,
+			&nt.ReadAt,
+			&nt.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, nt)
+	}
+
+	return
+}
+
+// GetByUserID returns the user's notifications newest first, only the unread
+// ones when unread is set.
+func (n *NotificationRepository) GetByUserID(ctx context.Context, userID int64, unread bool, cursor string, num int64) (res []domain.Notification, nextCursor string, err error) {
+	query := `SELECT id, user_id, kind, title, body, todo_id, read_at, created_at FROM notifications
+		WHERE user_id = $1 AND ($2::TIMESTAMPTZ IS NULL OR (created_at, id) < ($2, $3::BIGINT)) AND (NOT $4 OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC LIMIT $5`
+
+	var before *time.Time
+	var beforeID *int64
+	if cursor != "" {
+		createdAt, id, err := repository.DecodeKeyCursor(cursor)
+		if err != nil {
+			return nil, "", domain.ErrBadParamInput
+		}
+		before, beforeID = &createdAt, &id
+	}
+
+	res, err = n.fetch(ctx, query, userID, before, beforeID, unread, num)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(res) == int(num) {
+		last := res[len(res)-1]
+		nextCursor = repository.EncodeKeyCursor(last.CreatedAt, last.ID)
+	}
+
+	return
+}
+
+func (n *NotificationRepository) CountUnread(ctx context.Context, userID int64) (res int64, err error) {
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`
+
+	err = db(ctx, n.Conn).QueryRow(ctx, query, userID).Scan(&res)
+	return
+}
+
+func (n *NotificationRepository) Store(ctx context.Context, nt *domain.Notification) (err error) {
+	query := `INSERT INTO notifications (user_id, kind, title, body, todo_id, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	return db(ctx, n.Conn).QueryRow(ctx, query, nt.UserID, nt.Kind, nt.Title, nt.Body, nt.TodoID, nt.CreatedAt).Scan(&nt.ID)
+}
+
+// MarkRead marks one of the user's notifications read. Marking a read
+// notification again keeps its original read time.
+func (n *NotificationRepository) MarkRead(ctx context.Context, userID int64, id int64, readAt time.Time) (err error) {
+	query := `UPDATE notifications SET read_at = COALESCE(read_at, $1) WHERE id = $2 AND user_id = $3`
+
+	commandTag, err := db(ctx, n.Conn).Exec(ctx, query, readAt, id, userID)
+	if err != nil {
+		return
+	}
+
+	if commandTag.RowsAffected() != 1 {
+		return domain.ErrNotFound
+	}
+
+	return
+}
+
+func (n *NotificationRepository) MarkAllRead(ctx context.Context, userID int64, readAt time.Time) (res int64, err error) {
+	query := `UPDATE notifications SET read_at = $1 WHERE user_id = $2 AND read_at IS NULL`
+
+	commandTag, err := db(ctx, n.Conn).Exec(ctx, query, readAt, userID)
+	if err != nil {
+		return
+	}
+
+	return commandTag.RowsAffected(), nil
+}
@@ -0,0 +1,190 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// reminderDue joins u.timezone, the zone of the user reminders of the todo t
+// go to, and d.due_at, the instant t is due. Reminders of an all-day todo
+// count back from the start of its day in that zone.
+const reminderDue = `CROSS JOIN LATERAL (SELECT timezone FROM users WHERE id = COALESCE(t.assignee_id, t.user_id)) u
+	CROSS JOIN LATERAL (SELECT CASE WHEN t.all_day THEN (t.date AT TIME ZONE 'UTC') AT TIME ZONE u.timezone
+	ELSE t.date END AS due_at) d`
+
+type ReminderRepository struct {
+	Conn *pgxpool.Pool
+}
+
+func NewReminderRepository(conn *pgxpool.Pool) *ReminderRepository {
+	return &ReminderRepository{
+		Conn: conn,
+	}
+}
+
+func (r *ReminderRepository) GetByTodoID(ctx context.Context, todoID int64) (res []domain.Reminder, err error) {
+	query := `SELECT r.id, r.todo_id, r.offset_minutes, d.due_at - make_interval(mins => r.offset_minutes), r.sent_at, r.created_at
+		FROM todo_reminders r JOIN todos t ON t.id = r.todo_id ` + reminderDue + `
+		WHERE r.todo_id = $1 ORDER BY r.offset_minutes DESC`
+
+	rows, err := db(ctx, r.Conn).Query(ctx, query, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		rm := domain.Reminder{}
+		err = rows.Scan(
+			&rm.ID,
+			&rm.TodoID,
+			&rm.OffsetMinutes,
+			&rm.RemindAt,
+			&rm.SentAt,
+			&rm.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, rm)
+	}
+
+	return
+}
+
+// Replace makes offsets the reminders of the todo. Reminders that are kept
+// remember whether they were sent. Call it within a transaction.
+func (r *ReminderRepository) Replace(ctx context.Context, todoID int64, offsets []int, createdAt time.Time) (err error) {
+	query := `DELETE FROM todo_reminders WHERE todo_id = $1 AND NOT (offset_minutes = ANY($2))`
+
+	_, err = db(ctx, r.Conn).Exec(ctx, query, todoID, offsets)
+	if err != nil {
+		return
+	}
+
+	query = `INSERT INTO todo_reminders (todo_id, offset_minutes, created_at) SELECT $1, unnest($2::INT[]), $3
+		ON CONFLICT (todo_id, offset_minutes) DO NOTHING`
+
+	_, err = db(ctx, r.Conn).Exec(ctx, query, todoID, offsets, createdAt)
+	return
+}
+
+// LockDue locks up to limit reminders of open todos that are due and not yet
+// sent for the todo's current date. Reminders locked by another scheduler
+// are skipped. Call it within a transaction.
+func (r *ReminderRepository) LockDue(ctx context.Context, now time.Time, limit int64) (res []domain.DueReminder, err error) {
+	query := `SELECT r.id, r.todo_id, r.offset_minutes, d.due_at - make_interval(mins => r.offset_minutes), r.sent_at, r.created_at,
+		t.id, t.text, t.date, t.user_id, t.assignee_id, t.all_day, u.timezone
+		FROM todo_reminders r JOIN todos t ON t.id = r.todo_id ` + reminderDue + `
+		WHERE d.due_at - make_interval(mins => r.offset_minutes) <= $1 AND r.sent_for IS DISTINCT FROM t.date
+		AND NOT t.completed AND t.deleted_at IS NULL AND t.archived_at IS NULL
+		ORDER BY t.date LIMIT $2 FOR UPDATE OF r SKIP LOCKED`
+
+	rows, err := db(ctx, r.Conn).Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		due := domain.DueReminder{}
+		err = rows.Scan(
+			&due.Reminder.ID,
+			&due.Reminder.TodoID,
+			&due.Reminder.OffsetMinutes,
+			&due.Reminder.RemindAt,
+			&due.Reminder.SentAt,
+			&due.Reminder.CreatedAt,
+			&due.Todo.ID,
+			&due.Todo.Text,
+			&due.Todo.Date,
+			&due.Todo.UserID,
+			&due.Todo.AssigneeID,
+			&due.Todo.AllDay,
+			&due.Timezone,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, due)
+	}
+
+	return
+}
+
+func (r *ReminderRepository) MarkSent(ctx context.Context, id int64, sentFor time.Time, sentAt time.Time) (err error) {
+	query := `UPDATE todo_reminders SET sent_for = $1, sent_at = $2 WHERE id = $3`
+
+	_, err = db(ctx, r.Conn).Exec(ctx, query, sentFor, sentAt, id)
+	return
+}
+
+// Enqueue adds the notification of a reminder to the outbox once for every
+// channel. Call it in the transaction that marks the reminder sent.
+func (r *ReminderRepository) Enqueue(ctx context.Context, reminderID int64, channels []string, n domain.Notification) (err error) {
+	query := `INSERT INTO reminder_outbox (reminder_id, channel, notification, next_attempt_at, created_at)
+		SELECT $1, unnest($2::VARCHAR[]), $3::JSONB, $4::TIMESTAMPTZ, $4`
+
+	_, err = db(ctx, r.Conn).Exec(ctx, query, reminderID, channels, n, n.CreatedAt)
+	return
+}
+
+// ClaimDue takes up to limit pending messages whose next attempt is due by
+// moving their next attempt to leaseUntil, and returns them. Until the lease
+// runs out other dispatchers leave the messages alone.
+func (r *ReminderRepository) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int64) (res []domain.ReminderMessage, err error) {
+	query := `UPDATE reminder_outbox SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM reminder_outbox
+			WHERE delivered_at IS NULL AND failed_at IS NULL AND next_attempt_at <= $1
+			ORDER BY next_attempt_at LIMIT $3 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, channel, notification, attempts`
+
+	rows, err := db(ctx, r.Conn).Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		msg := domain.ReminderMessage{}
+		err = rows.Scan(&msg.ID, &msg.Channel, &msg.Notification, &msg.Attempts)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, msg)
+	}
+
+	return
+}
+
+func (r *ReminderRepository) MarkDelivered(ctx context.Context, id int64, attempts int, deliveredAt time.Time) (err error) {
+	query := `UPDATE reminder_outbox SET attempts = $1, error = '', delivered_at = $2 WHERE id = $3`
+
+	_, err = db(ctx, r.Conn).Exec(ctx, query, attempts, deliveredAt, id)
+	return
+}
+
+func (r *ReminderRepository) ScheduleRetry(ctx context.Context, id int64, attempts int, lastError string, nextAttemptAt time.Time) (err error) {
+	query := `UPDATE reminder_outbox SET attempts = $1, error = $2, next_attempt_at = $3 WHERE id = $4`
+
+	_, err = db(ctx, r.Conn).Exec(ctx, query, attempts, lastError, nextAttemptAt, id)
+	return
+}
+
+func (r *ReminderRepository) MarkFailed(ctx context.Context, id int64, attempts int, lastError string, failedAt time.Time) (err error) {
+	query := `UPDATE reminder_outbox SET attempts = $1, error = $2, failed_at = $3 WHERE id = $4`
+
+	_, err = db(ctx, r.Conn).Exec(ctx, query, attempts, lastError, failedAt, id)
+	return
+}
@@ -0,0 +1,118 @@
+package psql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// allDayReminder is a reminder on an all-day todo around the daylight saving
+// changes of 2024.
+type allDayReminder struct {
+	name     string
+	timezone string
+	// assigneeTimezone, when set, is the zone of an assignee the reminder
+	// goes to instead of the creator.
+	assigneeTimezone string
+	day              time.Time
+	offsetMinutes    int
+	// want is when the reminder is due.
+	want time.Time
+}
+
+var allDayReminders = []allDayReminder{
+	{"new york spring forward", "America/New_York", "", allDay(2024, time.March, 10), 0, utc(2024, time.March, 10, 5, 0)},
+	{"new york after spring forward", "America/New_York", "", allDay(2024, time.March, 11), 0, utc(2024, time.March, 11, 4, 0)},
+	{"new york fall back", "America/New_York", "", allDay(2024, time.November, 3), 0, utc(2024, time.November, 3, 4, 0)},
+	{"new york after fall back", "America/New_York", "", allDay(2024, time.November, 4), 0, utc(2024, time.November, 4, 5, 0)},
+	{"new york hour before", "America/New_York", "", allDay(2024, time.November, 4), 60, utc(2024, time.November, 4, 4, 0)},
+	{"sydney fall back", "Australia/Sydney", "", allDay(2024, time.April, 7), 0, utc(2024, time.April, 6, 13, 0)},
+	{"sydney after fall back", "Australia/Sydney", "", allDay(2024, time.April, 8), 0, utc(2024, time.April, 7, 14, 0)},
+	{"sydney spring forward", "Australia/Sydney", "", allDay(2024, time.October, 6), 0, utc(2024, time.October, 5, 14, 0)},
+	{"sydney after spring forward", "Australia/Sydney", "", allDay(2024, time.October, 7), 0, utc(2024, time.October, 6, 13, 0)},
+	{"sydney day before", "Australia/Sydney", "", allDay(2024, time.October, 7), 1440, utc(2024, time.October, 5, 13, 0)},
+	{"assignee's zone", "America/New_York", "Australia/Sydney", allDay(2024, time.October, 7), 0, utc(2024, time.October, 6, 13, 0)},
+}
+
+// insertReminder stores the todo of tt with its reminder and returns the ids
+// of both.
+func insertReminder(t *testing.T, ctx context.Context, pool *pgxpool.Pool, tt allDayReminder) (todoID int64, id int64) {
+	t.Helper()
+
+	userID := insertUser(t, ctx, pool, tt.timezone)
+	var assigneeID *int64
+	if tt.assigneeTimezone != "" {
+		id := insertUser(t, ctx, pool, tt.assigneeTimezone)
+		assigneeID = &id
+	}
+	todoID = insertTodo(t, ctx, pool, userID, assigneeID, tt.day, true)
+
+	query := `INSERT INTO todo_reminders (todo_id, offset_minutes) VALUES ($1, $2) RETURNING id`
+	if err := db(ctx, pool).QueryRow(ctx, query, todoID, tt.offsetMinutes).Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+
+	return
+}
+
+func TestLockDueAllDay(t *testing.T) {
+	ctx, pool := testContext(t)
+	repo := NewReminderRepository(pool)
+
+	for _, tt := range allDayReminders {
+		t.Run(tt.name, func(t *testing.T) {
+			_, id := insertReminder(t, ctx, pool, tt)
+
+			for _, c := range []struct {
+				now  time.Time
+				want bool
+			}{
+				{tt.want.Add(-time.Second), false},
+				{tt.want, true},
+			} {
+				due, err := repo.LockDue(ctx, c.now, 1<<30)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got := false
+				for _, d := range due {
+					if d.Reminder.ID != id {
+						continue
+					}
+					got = true
+					if !d.Reminder.RemindAt.Equal(tt.want) {
+						t.Errorf("remind at %s, want %s", d.Reminder.RemindAt.UTC().Format(time.RFC3339), tt.want.Format(time.RFC3339))
+					}
+				}
+				if got != c.want {
+					t.Errorf("due at %s = %v, want %v", c.now.Format(time.RFC3339), got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetByTodoIDAllDay(t *testing.T) {
+	ctx, pool := testContext(t)
+	repo := NewReminderRepository(pool)
+
+	for _, tt := range allDayReminders {
+		t.Run(tt.name, func(t *testing.T) {
+			todoID, id := insertReminder(t, ctx, pool, tt)
+
+			reminders, err := repo.GetByTodoID(ctx, todoID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(reminders) != 1 || reminders[0].ID != id {
+				t.Fatalf("reminders = %+v, want only %d", reminders, id)
+			}
+			if got := reminders[0].RemindAt; got == nil || !got.Equal(tt.want) {
+				t.Errorf("remind at %v, want %s", got, tt.want.Format(time.RFC3339))
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const selectTemplate = `SELECT id, user_id, name, description, project, items, updated_at, created_at FROM templates`
+
+type TemplateRepository struct {
+	Conn *pgxpool.Pool
+}
+
+func NewTemplateRepository(conn *pgxpool.Pool) *TemplateRepository {
+	return &TemplateRepository{
+		Conn: conn,
+	}
+}
+
+func (t *TemplateRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Template, err error) {
+	rows, err := db(ctx, t.Conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		tpl := domain.Template{}
+		err = rows.Scan(
+			&tpl.ID,
+			&tpl.UserID,
+			&tpl.Name,
+			&tpl.Description,
+			&tpl.Project,
+			&tpl.Items,
+			&tpl.UpdatedAt,
+			&tpl.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, tpl)
+	}
+
+	return
+}
+
+func (t *TemplateRepository) GetByUserID(ctx context.Context, userID int64) (res []domain.Template, err error) {
+	query := selectTemplate + ` WHERE user_id = $1 ORDER BY name, id`
+
+	res, err = t.fetch(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (t *TemplateRepository) GetByID(ctx context.Context, id int64) (res domain.Template, err error) {
+	query := selectTemplate + ` WHERE id = $1`
+
+	list, err := t.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Template{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (t *TemplateRepository) Store(ctx context.Context, tpl *domain.Template) (err error) {
+	query := `INSERT INTO templates (user_id, name, description, project, items, updated_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	return db(ctx, t.Conn).QueryRow(ctx, query, tpl.UserID, tpl.Name, tpl.Description, tpl.Project, tpl.Items, tpl.UpdatedAt, tpl.CreatedAt).Scan(&tpl.ID)
+}
+
+func (t *TemplateRepository) Update(ctx context.Context, tpl *domain.Template) (err error) {
+	query := `UPDATE templates SET name = $1, description = $2, project = $3, items = $4, updated_at = $5 WHERE id = $6`
+
+	commandTag, err := db(ctx, t.Conn).Exec(ctx, query, tpl.Name, tpl.Description, tpl.Project, tpl.Items, tpl.UpdatedAt, tpl.ID)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (t *TemplateRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := `DELETE FROM templates WHERE id = $1`
+
+	commandTag, err := db(ctx, t.Conn).Exec(ctx, query, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
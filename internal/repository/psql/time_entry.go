@@ -0,0 +1,156 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const selectTimeEntry = `SELECT id, todo_id, user_id, started_at, ended_at, note, created_at FROM time_entries`
+
+type TimeEntryRepository struct {
+	Conn *pgxpool.Pool
+}
+
+func NewTimeEntryRepository(conn *pgxpool.Pool) *TimeEntryRepository {
+	return &TimeEntryRepository{
+		Conn: conn,
+	}
+}
+
+func (t *TimeEntryRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.TimeEntry, err error) {
+	rows, err := db(ctx, t.Conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		te := domain.TimeEntry{}
+		err = rows.Scan(
+			&te.ID,
+			&te.TodoID,
+			&te.UserID,
+			&te.StartedAt,
+			&te.EndedAt,
+			&te.Note,
+			&te.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, te)
+	}
+
+	return
+}
+
+func (t *TimeEntryRepository) fetchOne(ctx context.Context, query string, args ...interface{}) (res domain.TimeEntry, err error) {
+	list, err := t.fetch(ctx, query, args...)
+	if err != nil {
+		return domain.TimeEntry{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (t *TimeEntryRepository) GetByTodoID(ctx context.Context, todoID int64) (res []domain.TimeEntry, err error) {
+	query := selectTimeEntry + ` WHERE todo_id = $1 ORDER BY started_at DESC, id DESC`
+
+	res, err = t.fetch(ctx, query, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (t *TimeEntryRepository) GetByID(ctx context.Context, id int64) (res domain.TimeEntry, err error) {
+	return t.fetchOne(ctx, selectTimeEntry+` WHERE id = $1`, id)
+}
+
+// GetRunning returns the user's running timer, or ErrNotFound.
+func (t *TimeEntryRepository) GetRunning(ctx context.Context, userID int64) (res domain.TimeEntry, err error) {
+	return t.fetchOne(ctx, selectTimeEntry+` WHERE user_id = $1 AND ended_at IS NULL`, userID)
+}
+
+// Store adds the entry. An entry without an end is a timer; storing one
+// while the user has another running fails with ErrConflict.
+func (t *TimeEntryRepository) Store(ctx context.Context, te *domain.TimeEntry) (err error) {
+	query := `INSERT INTO time_entries (todo_id, user_id, started_at, ended_at, note, created_at) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT DO NOTHING RETURNING id`
+
+	err = db(ctx, t.Conn).QueryRow(ctx, query, te.TodoID, te.UserID, te.StartedAt, te.EndedAt, te.Note, te.CreatedAt).Scan(&te.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrConflict
+	}
+
+	return
+}
+
+// StopRunning ends the user's running timer, if any.
+func (t *TimeEntryRepository) StopRunning(ctx context.Context, userID int64, endedAt time.Time) (err error) {
+	query := `UPDATE time_entries SET ended_at = GREATEST(started_at, $2) WHERE user_id = $1 AND ended_at IS NULL`
+
+	_, err = db(ctx, t.Conn).Exec(ctx, query, userID, endedAt)
+	return
+}
+
+func (t *TimeEntryRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := `DELETE FROM time_entries WHERE id = $1`
+
+	commandTag, err := db(ctx, t.Conn).Exec(ctx, query, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+// GetReport sums the user's entries started on the days from and to in
+// timezone by the category of their todo. Running timers count up to now.
+func (t *TimeEntryRepository) GetReport(ctx context.Context, userID int64, timezone string, from time.Time, to time.Time) (res domain.TimeReport, err error) {
+	query := `SELECT t.category, SUM(EXTRACT(EPOCH FROM COALESCE(e.ended_at, now()) - e.started_at))::bigint, COUNT(*)
+		FROM time_entries e JOIN todos t ON t.id = e.todo_id
+		WHERE e.user_id = $1 AND e.started_at >= $3::date::timestamp AT TIME ZONE $2 AND e.started_at < ($4::date + 1)::timestamp AT TIME ZONE $2
+		GROUP BY t.category ORDER BY 2 DESC, t.category`
+
+	rows, err := db(ctx, t.Conn).Query(ctx, query, userID, timezone, from, to)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+
+	res.ByCategory = []domain.CategoryTime{}
+	for rows.Next() {
+		var ct domain.CategoryTime
+		if err = rows.Scan(&ct.Category, &ct.Seconds, &ct.Entries); err != nil {
+			return
+		}
+
+		res.TotalSeconds += ct.Seconds
+		res.ByCategory = append(res.ByCategory, ct)
+	}
+
+	return res, rows.Err()
+}
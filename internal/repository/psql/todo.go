@@ -4,11 +4,29 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const selectTodo = `SELECT id, text, notes, category, date, all_day, priority, user_id, list_id, assignee_id, completed, recurrence_rule, estimate_minutes, position, completed_at, archived_at, updated_at, created_at, deleted_at FROM todos`
+
+// isBlocked holds for todos that wait on an open todo.
+const isBlocked = `EXISTS (SELECT 1 FROM todo_dependencies d JOIN todos b ON b.id = d.blocked_by_id
+	WHERE d.todo_id = todos.id AND NOT b.completed AND b.deleted_at IS NULL)`
+
+// visibleTo is the condition for todos the user in param can see: their
+// personal todos and the todos on lists they are a member of.
+func visibleTo(param string) string {
+	return `((list_id IS NULL AND user_id = ` + param + `) OR list_id IN (SELECT list_id FROM list_members WHERE user_id = ` + param + `))`
+}
+
+// positionStep is the gap left between neighbouring todos when they are
+// appended or rebalanced, so that later moves can land in between.
+const positionStep = 1024
+
 type TodoRepository struct {
 	Conn *pgxpool.Pool
 }
@@ -20,7 +38,7 @@ func NewTodoRepository(conn *pgxpool.Pool) *TodoRepository {
 }
 
 func (t *TodoRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Todo, err error) {
-	rows, err := t.Conn.Query(ctx, query, args...)
+	rows, err := db(ctx, t.Conn).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -29,17 +47,7 @@ func (t *TodoRepository) fetch(ctx context.Context, query string, args ...interf
 
 	for rows.Next() {
 		td := domain.Todo{}
-		err = rows.Scan(
-			&td.ID,
-			&td.Text,
-			&td.Category,
-			&td.Date,
-			&td.PriorityLevel,
-			&td.UserID,
-			&td.Completed,
-			&td.UpdatedAt,
-			&td.CreatedAt,
-		)
+		err = scanTodo(rows, &td)
 		if err != nil {
 			return nil, err
 		}
@@ -50,8 +58,105 @@ func (t *TodoRepository) fetch(ctx context.Context, query string, args ...interf
 	return
 }
 
+// scanTodo scans a row of selectTodo. The priority is stored as its rank.
+func scanTodo(row pgx.Row, td *domain.Todo) error {
+	var priority int
+	err := row.Scan(
+		&td.ID,
+		&td.Text,
+		&td.Notes,
+		&td.Category,
+		&td.Date,
+		&td.AllDay,
+		&priority,
+		&td.UserID,
+		&td.ListID,
+		&td.AssigneeID,
+		&td.Completed,
+		&td.RecurrenceRule,
+		&td.EstimateMinutes,
+		&td.Position,
+		&td.CompletedAt,
+		&td.ArchivedAt,
+		&td.UpdatedAt,
+		&td.CreatedAt,
+		&td.DeletedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	td.PriorityLevel = domain.PriorityFromRank(priority)
+	return nil
+}
+
+// GetTrackedSeconds returns the time tracked on each of the given todos,
+// including running timers up to now. Todos without time entries are left
+// out.
+func (t *TodoRepository) GetTrackedSeconds(ctx context.Context, ids []int64) (res map[int64]int64, err error) {
+	query := `SELECT todo_id, SUM(EXTRACT(EPOCH FROM COALESCE(ended_at, now()) - started_at))::bigint
+		FROM time_entries WHERE todo_id = ANY($1) GROUP BY todo_id`
+
+	rows, err := db(ctx, t.Conn).Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res = make(map[int64]int64, len(ids))
+	for rows.Next() {
+		var todoID, seconds int64
+		if err = rows.Scan(&todoID, &seconds); err != nil {
+			return nil, err
+		}
+		res[todoID] = seconds
+	}
+
+	return res, rows.Err()
+}
+
+// GetDependencies returns the todos each of the given todos waits on and the
+// ones waiting on it, in order of id. Only todos the user can see, personal
+// or shared, are listed, and deleted ones are left out.
+func (t *TodoRepository) GetDependencies(ctx context.Context, userID int64, ids []int64) (blockedBy map[int64][]int64, blocking map[int64][]int64, err error) {
+	query := `SELECT d.todo_id, d.blocked_by_id, true FROM todo_dependencies d JOIN todos b ON b.id = d.blocked_by_id
+			WHERE d.todo_id = ANY($1) AND b.deleted_at IS NULL
+			AND ((b.list_id IS NULL AND b.user_id = $2) OR b.list_id IN (SELECT list_id FROM list_members WHERE user_id = $2))
+		UNION ALL
+		SELECT d.blocked_by_id, d.todo_id, false FROM todo_dependencies d JOIN todos b ON b.id = d.todo_id
+			WHERE d.blocked_by_id = ANY($1) AND b.deleted_at IS NULL
+			AND ((b.list_id IS NULL AND b.user_id = $2) OR b.list_id IN (SELECT list_id FROM list_members WHERE user_id = $2))
+		ORDER BY 1, 2`
+
+	rows, err := db(ctx, t.Conn).Query(ctx, query, ids, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	blockedBy = map[int64][]int64{}
+	blocking = map[int64][]int64{}
+	for rows.Next() {
+		var todoID, otherID int64
+		var isBlocker bool
+		if err = rows.Scan(&todoID, &otherID, &isBlocker); err != nil {
+			return nil, nil, err
+		}
+
+		if isBlocker {
+			blockedBy[todoID] = append(blockedBy[todoID], otherID)
+		} else {
+			blocking[todoID] = append(blocking[todoID], otherID)
+		}
+	}
+
+	return blockedBy, blocking, rows.Err()
+}
+
 func (t *TodoRepository) Fetch(ctx context.Context, limit int64, offset int64) (res []domain.Todo, err error) {
-	query := `SELECT id,text,category,date,priority_level,user_id,completed,updated_at,created_at FROM todos ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+	query := selectTodo + ` WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT $1 OFFSET $2`
 
 	res, err = t.fetch(ctx, query, limit, offset)
 	if err != nil {
@@ -62,7 +167,7 @@ func (t *TodoRepository) Fetch(ctx context.Context, limit int64, offset int64) (
 }
 
 func (t *TodoRepository) GetByID(ctx context.Context, id int64) (res domain.Todo, err error) {
-	query := `SELECT id,text,category,date,priority_level,user_id,completed,updated_at,created_at FROM todos WHERE ID = $1`
+	query := selectTodo + ` WHERE id = $1 AND deleted_at IS NULL`
 
 	list, err := t.fetch(ctx, query, id)
 	if err != nil {
@@ -78,43 +183,185 @@ func (t *TodoRepository) GetByID(ctx context.Context, id int64) (res domain.Todo
 	return
 }
 
-func (t *TodoRepository) GetByUserID(ctx context.Context, userID int64, limit int64, offset int64, category *string, priorityLevel *string, keyword *string) (res []domain.Todo, err error) {
-    query := `SELECT id, text, category, date, priority_level, user_id, completed, updated_at, created_at FROM todos WHERE user_id = $1`
-    
-    params := []interface{}{userID}
-    paramIndex := 2
+func (t *TodoRepository) GetTrashedByID(ctx context.Context, id int64) (res domain.Todo, err error) {
+	query := selectTodo + ` WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	list, err := t.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+// GetByIDsForUpdate loads the given todos and locks their rows until the
+// surrounding transaction ends. It must be called within a transaction.
+func (t *TodoRepository) GetByIDsForUpdate(ctx context.Context, ids []int64) (res []domain.Todo, err error) {
+	query := selectTodo + ` WHERE id = ANY($1) AND deleted_at IS NULL ORDER BY id FOR UPDATE`
+
+	res, err = t.fetch(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (t *TodoRepository) GetByUserID(ctx context.Context, userID int64, limit int64, offset int64, filter domain.TodoFilter) (res []domain.Todo, err error) {
+	where, params := filterTodos(userID, filter, time.Now())
+	paramIndex := len(params) + 1
+
+	query := selectTodo + where + orderTodos(filter)
+	query += ` LIMIT $` + strconv.Itoa(paramIndex) + ` OFFSET $` + strconv.Itoa(paramIndex+1)
+	params = append(params, limit, offset)
+
+	res, err = t.fetch(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// StreamByUserID calls fn for every todo matching the filter, in the order
+// of GetByUserID, as the rows come in from the database rather than
+// collecting them first. Iteration stops at the first error fn returns.
+func (t *TodoRepository) StreamByUserID(ctx context.Context, userID int64, filter domain.TodoFilter, fn func(domain.Todo) error) (err error) {
+	where, params := filterTodos(userID, filter, time.Now())
+
+	rows, err := db(ctx, t.Conn).Query(ctx, selectTodo+where+orderTodos(filter), params...)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		td := domain.Todo{}
+		if err = scanTodo(rows, &td); err != nil {
+			return
+		}
+		if err = fn(td); err != nil {
+			return
+		}
+	}
+
+	return rows.Err()
+}
+
+// filterTodos builds the WHERE clause shared by the todo list and export.
+// The due filters are relative to now.
+func filterTodos(userID int64, filter domain.TodoFilter, now time.Time) (query string, params []interface{}) {
+	query = ` WHERE deleted_at IS NULL`
+	paramIndex := 1
+
+	// Without a list scope the user sees every todo visible to them: a
+	// personal todo only to its creator, a list todo to the list's members.
+	switch {
+	case filter.ListID != nil:
+		query += ` AND list_id = $` + strconv.Itoa(paramIndex)
+		params = append(params, *filter.ListID)
+		paramIndex++
+	default:
+		query += ` AND ` + visibleTo(`$`+strconv.Itoa(paramIndex))
+		params = append(params, userID)
+		paramIndex++
+	}
+	if filter.Assigned == domain.AssignedMe {
+		query += ` AND assignee_id = $` + strconv.Itoa(paramIndex)
+		params = append(params, userID)
+		paramIndex++
+	}
+
+	if filter.Archived {
+		query += ` AND archived_at IS NOT NULL`
+	} else {
+		query += ` AND archived_at IS NULL`
+	}
 
-    if category != nil && *category != "" {
-        query += ` AND category = $` + strconv.Itoa(paramIndex)
-        params = append(params, *category)
-        paramIndex++
-    }
-    if priorityLevel != nil && *priorityLevel != "" {
-        query += ` AND priority_level = $` + strconv.Itoa(paramIndex)
-        params = append(params, *priorityLevel)
-        paramIndex++
-    }
-    if keyword != nil && *keyword != "" {
-        query += ` AND text ILIKE '%' || $` + strconv.Itoa(paramIndex) + ` || '%'`
-        params = append(params, *keyword)
-        paramIndex++
-    }
+	if filter.Category != "" {
+		query += ` AND category = $` + strconv.Itoa(paramIndex)
+		params = append(params, filter.Category)
+		paramIndex++
+	}
+	if filter.PriorityLevel != "" {
+		query += ` AND priority = $` + strconv.Itoa(paramIndex)
+		params = append(params, filter.PriorityLevel.Rank())
+		paramIndex++
+	}
+	if filter.Keyword != "" {
+		query += ` AND (text ILIKE '%' || $` + strconv.Itoa(paramIndex) + ` || '%' OR notes ILIKE '%' || $` + strconv.Itoa(paramIndex) + ` || '%')`
+		params = append(params, filter.Keyword)
+		paramIndex++
+	}
+
+	if filter.Blocked != nil {
+		if *filter.Blocked {
+			query += ` AND ` + isBlocked
+		} else {
+			query += ` AND NOT ` + isBlocked
+		}
+	}
 
-    query += ` ORDER BY created_at DESC LIMIT $` + strconv.Itoa(paramIndex) + ` OFFSET $` + strconv.Itoa(paramIndex+1)
-    params = append(params, limit, offset)
+	// The current day is the one in the requesting user's zone. An all-day
+	// todo is compared by its calendar day, a timed one by its instant.
+	zone := `(SELECT timezone FROM users WHERE id = $` + strconv.Itoa(paramIndex) + `)`
+	current := `$` + strconv.Itoa(paramIndex+1) + `::TIMESTAMPTZ`
+	switch filter.Due {
+	case domain.DueToday:
+		query += ` AND CASE WHEN all_day THEN (date AT TIME ZONE 'UTC')::date = (` + current + ` AT TIME ZONE ` + zone + `)::date
+			ELSE date >= date_trunc('day', ` + current + ` AT TIME ZONE ` + zone + `) AT TIME ZONE ` + zone + `
+			AND date < (date_trunc('day', ` + current + ` AT TIME ZONE ` + zone + `) + INTERVAL '1 day') AT TIME ZONE ` + zone + ` END`
+		params = append(params, userID, now)
+	case domain.DueOverdue:
+		query += ` AND NOT completed AND CASE WHEN all_day THEN (date AT TIME ZONE 'UTC')::date < (` + current + ` AT TIME ZONE ` + zone + `)::date
+			ELSE date < ` + current + ` END`
+		params = append(params, userID, now)
+	case domain.DueUpcoming:
+		query += ` AND NOT completed AND CASE WHEN all_day THEN (date AT TIME ZONE 'UTC')::date > (` + current + ` AT TIME ZONE ` + zone + `)::date
+			ELSE date >= (date_trunc('day', ` + current + ` AT TIME ZONE ` + zone + `) + INTERVAL '1 day') AT TIME ZONE ` + zone + ` END`
+		params = append(params, userID, now)
+	case domain.DueNone:
+		query += ` AND date IS NULL`
+	}
 
-    res, err = t.fetch(ctx, query, params...)
-    if err != nil {
-        return nil, err
-    }
+	return
+}
 
-    return
+func orderTodos(filter domain.TodoFilter) string {
+	switch filter.Sort {
+	case domain.SortPosition:
+		return ` ORDER BY category, position, id`
+	case domain.SortDate:
+		return ` ORDER BY date, id`
+	case domain.SortPriority:
+		return ` ORDER BY priority, date, id`
+	default:
+		return ` ORDER BY created_at DESC`
+	}
+}
+
+func (t *TodoRepository) GetTrashByUserID(ctx context.Context, userID int64, limit int64, offset int64) (res []domain.Todo, err error) {
+	query := selectTodo + ` WHERE ` + visibleTo(`$1`) + ` AND deleted_at IS NOT NULL ORDER BY deleted_at DESC LIMIT $2 OFFSET $3`
+
+	res, err = t.fetch(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return
 }
 
 func (t *TodoRepository) GetAllCategories(ctx context.Context) (res []string, err error) {
-	query := `SELECT DISTINCT category FROM todos`
+	query := `SELECT DISTINCT category FROM todos WHERE deleted_at IS NULL`
 
-	rows, err := t.Conn.Query(ctx, query)
+	rows, err := db(ctx, t.Conn).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -135,9 +382,11 @@ func (t *TodoRepository) GetAllCategories(ctx context.Context) (res []string, er
 }
 
 func (t *TodoRepository) Store(ctx context.Context, td *domain.Todo) (err error) {
-	query := `INSERT INTO todos (text, category, date, priority_level, user_id, updated_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) returning id`
+	query := `INSERT INTO todos (text, category, date, priority, user_id, position, updated_at, created_at, notes, list_id, assignee_id, recurrence_rule, all_day, estimate_minutes)
+		VALUES ($1, $2, $3, $4, $5, COALESCE((SELECT MAX(position) FROM todos WHERE user_id = $5 AND category = $2), 0) + $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		returning id, position`
 
-	err = t.Conn.QueryRow(ctx, query, td.Text, td.Category, td.Date, td.PriorityLevel, td.UserID, td.UpdatedAt, td.CreatedAt).Scan(&td.ID)
+	err = db(ctx, t.Conn).QueryRow(ctx, query, td.Text, td.Category, td.Date, td.PriorityLevel.Rank(), td.UserID, positionStep, td.UpdatedAt, td.CreatedAt, td.Notes, td.ListID, td.AssigneeID, td.RecurrenceRule, td.AllDay, td.EstimateMinutes).Scan(&td.ID, &td.Position)
 	if err != nil {
 		return
 	}
@@ -145,10 +394,44 @@ func (t *TodoRepository) Store(ctx context.Context, td *domain.Todo) (err error)
 	return
 }
 
-func (t *TodoRepository) Delete(ctx context.Context, id int64) (err error) {
+func (t *TodoRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) (err error) {
+	query := `UPDATE todos SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`
+
+	commandTag, err := db(ctx, t.Conn).Exec(ctx, query, deletedAt, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (t *TodoRepository) Restore(ctx context.Context, id int64, updatedAt time.Time) (err error) {
+	query := `UPDATE todos SET deleted_at = NULL, updated_at = $1 WHERE id = $2 AND deleted_at IS NOT NULL`
+
+	commandTag, err := db(ctx, t.Conn).Exec(ctx, query, updatedAt, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (t *TodoRepository) HardDelete(ctx context.Context, id int64) (err error) {
 	query := `DELETE FROM todos WHERE id = $1`
 
-	commandTag, err := t.Conn.Exec(ctx, query, id)
+	commandTag, err := db(ctx, t.Conn).Exec(ctx, query, id)
 	if err != nil {
 		return
 	}
@@ -162,10 +445,70 @@ func (t *TodoRepository) Delete(ctx context.Context, id int64) (err error) {
 	return
 }
 
+// PurgeTrash permanently removes every todo that was moved to the trash
+// before the given time and returns how many rows were deleted.
+func (t *TodoRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time) (res int64, err error) {
+	query := `DELETE FROM todos WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+
+	commandTag, err := db(ctx, t.Conn).Exec(ctx, query, deletedBefore)
+	if err != nil {
+		return
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
 func (t *TodoRepository) Update(ctx context.Context, td *domain.Todo) (err error) {
-	query := `UPDATE todos SET text=$1, category=$2, date=$3, priority_level=$4, user_id=$5, completed=$6, updated_at=$7 WHERE id=$8`
+	// A todo that changes category goes to the end of its new category.
+	query := `UPDATE todos SET text=$1, category=$2, date=$3, priority=$4, user_id=$5, completed=$6, updated_at=$7,
+		completed_at = CASE WHEN $6 THEN COALESCE(completed_at, $7) ELSE NULL END, notes = $10, list_id = $11, assignee_id = $12, recurrence_rule = $13, all_day = $14, estimate_minutes = $15,
+		overdue_notified_at = CASE WHEN date IS NOT DISTINCT FROM $3 AND all_day = $14 THEN overdue_notified_at ELSE NULL END,
+		position = CASE WHEN category = $2 THEN position ELSE COALESCE((SELECT MAX(position) FROM todos WHERE user_id = $5 AND category = $2), 0) + $9 END
+		WHERE id=$8 AND deleted_at IS NULL`
+
+	commandTag, err := db(ctx, t.Conn).Exec(ctx, query, td.Text, td.Category, td.Date, td.PriorityLevel.Rank(), td.UserID, td.Completed, td.UpdatedAt, td.ID, positionStep, td.Notes, td.ListID, td.AssigneeID, td.RecurrenceRule, td.AllDay, td.EstimateMinutes)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
 
-	commandTag, err := t.Conn.Exec(ctx, query, td.Text, td.Category, td.Date, td.PriorityLevel, td.UserID, td.Completed, td.UpdatedAt, td.ID)
+// NeighbourPosition returns the closest position after (or, when before is
+// set, before) the given one within a user's category, ignoring excludeID.
+// found is false when there is no such todo, and res is then the position one
+// step past the given one, where a todo appended there would go.
+func (t *TodoRepository) NeighbourPosition(ctx context.Context, userID int64, category string, position float64, before bool, excludeID int64) (res float64, found bool, err error) {
+	query := `SELECT MIN(position) FROM todos WHERE user_id = $1 AND category = $2 AND deleted_at IS NULL AND position > $3 AND id <> $4`
+	if before {
+		query = `SELECT MAX(position) FROM todos WHERE user_id = $1 AND category = $2 AND deleted_at IS NULL AND position < $3 AND id <> $4`
+	}
+
+	var neighbour *float64
+	err = db(ctx, t.Conn).QueryRow(ctx, query, userID, category, position, excludeID).Scan(&neighbour)
+	if err != nil {
+		return
+	}
+	if neighbour == nil {
+		if before {
+			return position - positionStep, false, nil
+		}
+		return position + positionStep, false, nil
+	}
+
+	return *neighbour, true, nil
+}
+
+func (t *TodoRepository) UpdatePosition(ctx context.Context, id int64, position float64, updatedAt time.Time) (err error) {
+	query := `UPDATE todos SET position = $1, updated_at = $2 WHERE id = $3 AND deleted_at IS NULL`
+
+	commandTag, err := db(ctx, t.Conn).Exec(ctx, query, position, updatedAt, id)
 	if err != nil {
 		return
 	}
@@ -178,3 +521,370 @@ func (t *TodoRepository) Update(ctx context.Context, td *domain.Todo) (err error
 
 	return
 }
+
+// RebalancePositions spreads the positions of a user's category evenly again,
+// keeping their order. It is only needed once repeated moves into the same
+// gap have exhausted the float precision between two neighbours.
+func (t *TodoRepository) RebalancePositions(ctx context.Context, userID int64, category string) (err error) {
+	query := `UPDATE todos t SET position = r.rn * $3
+		FROM (SELECT id, row_number() OVER (ORDER BY position, id) AS rn FROM todos WHERE user_id = $1 AND category = $2 AND deleted_at IS NULL) r
+		WHERE t.id = r.id`
+
+	_, err = db(ctx, t.Conn).Exec(ctx, query, userID, category, positionStep)
+	return
+}
+
+func (t *TodoRepository) SetArchived(ctx context.Context, id int64, archivedAt *time.Time, updatedAt time.Time) (err error) {
+	query := `UPDATE todos SET archived_at = $1, updated_at = $2 WHERE id = $3 AND deleted_at IS NULL`
+
+	commandTag, err := db(ctx, t.Conn).Exec(ctx, query, archivedAt, updatedAt, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (t *TodoRepository) ArchiveCompleted(ctx context.Context, userID int64, archivedAt time.Time) (res []int64, err error) {
+	query := `UPDATE todos SET archived_at = $1, updated_at = $1 WHERE user_id = $2 AND completed AND archived_at IS NULL AND deleted_at IS NULL RETURNING id`
+
+	rows, err := db(ctx, t.Conn).Query(ctx, query, archivedAt, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err = pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// AutoArchive archives, for every user who enabled it, the todos that were
+// completed more than that user's auto_archive_days ago. It returns the ids
+// of the archived todos mapped to the users who created them.
+func (t *TodoRepository) AutoArchive(ctx context.Context, now time.Time) (res map[int64]int64, err error) {
+	query := `UPDATE todos t SET archived_at = $1, updated_at = $1
+		FROM users u
+		WHERE t.user_id = u.id AND u.auto_archive_days IS NOT NULL
+		AND t.completed AND t.archived_at IS NULL AND t.deleted_at IS NULL
+		AND t.completed_at < $1 - make_interval(days => u.auto_archive_days)
+		RETURNING t.id, t.user_id`
+
+	rows, err := db(ctx, t.Conn).Query(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res = map[int64]int64{}
+	for rows.Next() {
+		var id, userID int64
+		if err = rows.Scan(&id, &userID); err != nil {
+			return nil, err
+		}
+		res[id] = userID
+	}
+
+	return res, rows.Err()
+}
+
+func (t *TodoRepository) SetAssignee(ctx context.Context, id int64, assigneeID *int64, updatedAt time.Time) (err error) {
+	query := `UPDATE todos SET assignee_id = $1, updated_at = $2 WHERE id = $3 AND deleted_at IS NULL`
+
+	commandTag, err := db(ctx, t.Conn).Exec(ctx, query, assigneeID, updatedAt, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+// LockOverdue locks up to limit open todos that became due before now and
+// have not been reported as overdue yet. Rows locked by another instance are
+// skipped. Call it within a transaction.
+func (t *TodoRepository) LockOverdue(ctx context.Context, now time.Time, limit int64) (res []domain.Todo, err error) {
+	// An all-day todo is overdue once its day has ended in its creator's zone.
+	query := selectTodo + ` WHERE CASE WHEN all_day
+			THEN ((date AT TIME ZONE 'UTC')::date + 1)::timestamp AT TIME ZONE (SELECT timezone FROM users WHERE id = todos.user_id)
+			ELSE date END <= $1
+		AND NOT completed AND overdue_notified_at IS NULL
+		AND deleted_at IS NULL AND archived_at IS NULL
+		ORDER BY date LIMIT $2 FOR UPDATE SKIP LOCKED`
+
+	res, err = t.fetch(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (t *TodoRepository) SetOverdueNotified(ctx context.Context, ids []int64, notifiedAt time.Time) (err error) {
+	query := `UPDATE todos SET overdue_notified_at = $1 WHERE id = ANY($2)`
+
+	_, err = db(ctx, t.Conn).Exec(ctx, query, notifiedAt, ids)
+	return
+}
+
+// GetCalendar returns the todos the user can see, personal and shared, that
+// have a date and are neither archived nor in the trash, ordered by date.
+func (t *TodoRepository) GetCalendar(ctx context.Context, userID int64) (res []domain.Todo, err error) {
+	query := selectTodo + ` WHERE ` + visibleTo(`$1`) + `
+		AND date IS NOT NULL AND deleted_at IS NULL AND archived_at IS NULL
+		ORDER BY date, id`
+
+	res, err = t.fetch(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// StoreMany inserts the todos of one user in bulk with COPY and sets their
+// ids and positions. Each todo goes to the end of its category, keeping the
+// order given. Call it within a transaction so the positions cannot be taken
+// in between.
+func (t *TodoRepository) StoreMany(ctx context.Context, userID int64, todos []domain.Todo) (res int64, err error) {
+	query := `SELECT category, MAX(position) FROM todos WHERE user_id = $1 GROUP BY category`
+
+	rows, err := db(ctx, t.Conn).Query(ctx, query, userID)
+	if err != nil {
+		return
+	}
+
+	positions := map[string]float64{}
+	for rows.Next() {
+		var category string
+		var position float64
+		if err = rows.Scan(&category, &position); err != nil {
+			rows.Close()
+			return
+		}
+		positions[category] = position
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	// COPY returns nothing, so the ids are drawn from the sequence up front.
+	query = `SELECT nextval(pg_get_serial_sequence('todos', 'id')) FROM generate_series(1, $1)`
+
+	rows, err = db(ctx, t.Conn).Query(ctx, query, len(todos))
+	if err != nil {
+		return
+	}
+
+	for i := 0; rows.Next(); i++ {
+		if err = rows.Scan(&todos[i].ID); err != nil {
+			rows.Close()
+			return
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	columns := []string{"id", "text", "notes", "category", "date", "all_day", "priority", "completed", "completed_at", "recurrence_rule", "estimate_minutes", "user_id", "list_id", "position", "updated_at", "created_at"}
+
+	return db(ctx, t.Conn).CopyFrom(ctx, pgx.Identifier{"todos"}, columns, pgx.CopyFromSlice(len(todos), func(i int) ([]interface{}, error) {
+		td := &todos[i]
+		positions[td.Category] += positionStep
+		td.Position = positions[td.Category]
+
+		return []interface{}{td.ID, td.Text, td.Notes, td.Category, td.Date, td.AllDay, int16(td.PriorityLevel.Rank()), td.Completed, td.CompletedAt, td.RecurrenceRule, td.EstimateMinutes, userID, td.ListID, td.Position, td.UpdatedAt, td.CreatedAt}, nil
+	}))
+}
+
+// GetStats aggregates the todos the user can see, personal and shared. Days are calendar days in
+// timezone, from and to included.
+func (t *TodoRepository) GetStats(ctx context.Context, userID int64, timezone string, from time.Time, to time.Time) (res domain.Stats, err error) {
+	res.ByCategory = map[string]domain.StatsCount{}
+	res.ByPriority = map[domain.PriorityLevel]domain.StatsCount{}
+
+	// GROUPING tells the sets apart: 1 for a category row, 2 for a priority
+	// row and 3 for the grand total.
+	query := `SELECT GROUPING(category, priority), COALESCE(category, ''), COALESCE(priority, 0),
+		COUNT(*), COUNT(*) FILTER (WHERE completed)
+		FROM todos WHERE ` + visibleTo(`$1`) + ` AND deleted_at IS NULL
+		GROUP BY GROUPING SETS ((category), (priority), ())`
+
+	rows, err := db(ctx, t.Conn).Query(ctx, query, userID)
+	if err != nil {
+		return
+	}
+	for rows.Next() {
+		var grouping int
+		var category string
+		var priority int
+		var count domain.StatsCount
+		if err = rows.Scan(&grouping, &category, &priority, &count.Total, &count.Completed); err != nil {
+			rows.Close()
+			return
+		}
+		count.Open = count.Total - count.Completed
+
+		switch grouping {
+		case 1:
+			res.ByCategory[category] = count
+		case 2:
+			res.ByPriority[domain.PriorityFromRank(priority)] = count
+		default:
+			res.Totals = count
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	// Days without completions are filled in by the series.
+	query = `SELECT to_char(d.day, 'YYYY-MM-DD'), COUNT(t.day)
+		FROM generate_series($3::date::timestamp, $4::date::timestamp, INTERVAL '1 day') AS d (day)
+		LEFT JOIN (SELECT date_trunc('day', completed_at AT TIME ZONE $2) AS day FROM todos
+			WHERE ` + visibleTo(`$1`) + ` AND completed AND deleted_at IS NULL
+			AND completed_at >= $3::date::timestamp AT TIME ZONE $2 AND completed_at < ($4::date + 1)::timestamp AT TIME ZONE $2) t ON t.day = d.day
+		GROUP BY d.day ORDER BY d.day`
+
+	rows, err = db(ctx, t.Conn).Query(ctx, query, userID, timezone, from, to)
+	if err != nil {
+		return
+	}
+	for rows.Next() {
+		var day domain.DailyCount
+		if err = rows.Scan(&day.Date, &day.Count); err != nil {
+			rows.Close()
+			return
+		}
+		res.CompletedPerDay = append(res.CompletedPerDay, day)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	query = `SELECT AVG(EXTRACT(EPOCH FROM completed_at - created_at))::bigint FROM todos
+		WHERE ` + visibleTo(`$1`) + ` AND completed AND deleted_at IS NULL
+		AND completed_at >= $3::date::timestamp AT TIME ZONE $2 AND completed_at < ($4::date + 1)::timestamp AT TIME ZONE $2`
+
+	err = db(ctx, t.Conn).QueryRow(ctx, query, userID, timezone, from, to).Scan(&res.AverageCompletion)
+	if err != nil {
+		return
+	}
+
+	// Consecutive days keep day + row number constant when counted back from
+	// the latest one. The streak is still running when that day is today or
+	// yesterday.
+	query = `WITH days AS (SELECT DISTINCT date_trunc('day', completed_at AT TIME ZONE $2)::date AS day FROM todos
+			WHERE ` + visibleTo(`$1`) + ` AND completed AND deleted_at IS NULL),
+		runs AS (SELECT day + ROW_NUMBER() OVER (ORDER BY day DESC)::int AS run FROM days)
+		SELECT COUNT(*) FROM runs
+		WHERE run = (SELECT MAX(day) + 1 FROM days HAVING MAX(day) >= (now() AT TIME ZONE $2)::date - 1)`
+
+	err = db(ctx, t.Conn).QueryRow(ctx, query, userID, timezone).Scan(&res.CurrentStreak)
+	if err != nil {
+		return
+	}
+
+	where, params := filterTodos(userID, domain.TodoFilter{Due: domain.DueOverdue}, time.Now())
+	err = db(ctx, t.Conn).QueryRow(ctx, `SELECT COUNT(*) FROM todos`+where, params...).Scan(&res.Overdue)
+
+	return
+}
+
+// AddDependency makes the todo blocked by another one, or fails with
+// ErrDependencyCycle when that todo already waits on it, directly or through
+// others. Call it within a transaction that has locked the todo.
+//
+// The blocker and every todo it waits on are locked before the check. A
+// dependency is only added or removed with its todo locked, so the chain
+// cannot grow under the check and concurrent additions cannot close a cycle
+// between them. Additions in unrelated chains do not wait on each other.
+func (t *TodoRepository) AddDependency(ctx context.Context, todoID int64, blockedByID int64, createdAt time.Time) (err error) {
+	query := `WITH RECURSIVE chain AS (
+			SELECT $1::BIGINT AS id
+			UNION
+			SELECT d.blocked_by_id FROM todo_dependencies d JOIN chain c ON d.todo_id = c.id
+		)
+		SELECT id FROM todos WHERE id IN (SELECT id FROM chain) ORDER BY id FOR UPDATE`
+
+	// The chain is read again after each round of locks, as it may have grown
+	// while waiting on them, until every todo in it is locked.
+	locked := map[int64]bool{}
+	for {
+		rows, err := db(ctx, t.Conn).Query(ctx, query, blockedByID)
+		if err != nil {
+			return err
+		}
+
+		chain, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return err
+		}
+
+		grown := false
+		for _, id := range chain {
+			if id == todoID {
+				return domain.ErrDependencyCycle
+			}
+			if !locked[id] {
+				locked[id] = true
+				grown = true
+			}
+		}
+		if !grown {
+			break
+		}
+	}
+
+	query = `INSERT INTO todo_dependencies (todo_id, blocked_by_id, created_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+
+	_, err = db(ctx, t.Conn).Exec(ctx, query, todoID, blockedByID, createdAt)
+	return
+}
+
+func (t *TodoRepository) RemoveDependency(ctx context.Context, todoID int64, blockedByID int64) (err error) {
+	query := `DELETE FROM todo_dependencies WHERE todo_id = $1 AND blocked_by_id = $2`
+
+	commandTag, err := db(ctx, t.Conn).Exec(ctx, query, todoID, blockedByID)
+	if err != nil {
+		return
+	}
+
+	if commandTag.RowsAffected() != 1 {
+		return domain.ErrNotFound
+	}
+
+	return
+}
+
+// GetUnblocked returns the open todos blocked by the given one that no longer
+// wait on any open todo. Call it after the blocker has been completed.
+func (t *TodoRepository) GetUnblocked(ctx context.Context, blockerID int64) (res []domain.Todo, err error) {
+	query := selectTodo + ` WHERE id IN (SELECT todo_id FROM todo_dependencies WHERE blocked_by_id = $1)
+		AND NOT completed AND deleted_at IS NULL AND NOT ` + isBlocked + ` ORDER BY id`
+
+	res, err = t.fetch(ctx, query, blockerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
@@ -0,0 +1,189 @@
+package psql
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+// The days and instants below are around the daylight saving changes of
+// 2024: New York springs forward on March 10 and falls back on November 3,
+// Sydney falls back on April 7 and springs forward on October 6.
+
+func TestFilterTodosDue(t *testing.T) {
+	ctx, pool := testContext(t)
+
+	type todo struct {
+		date   time.Time
+		allDay bool
+	}
+	type check struct {
+		now  time.Time
+		due  string
+		want []string
+	}
+
+	tests := []struct {
+		name     string
+		timezone string
+		todos    map[string]todo
+		checks   []check
+	}{
+		{
+			name:     "new york spring forward",
+			timezone: "America/New_York",
+			todos: map[string]todo{
+				"day before":    {allDay(2024, time.March, 9), true},
+				"day":           {allDay(2024, time.March, 10), true},
+				"day after":     {allDay(2024, time.March, 11), true},
+				"late evening":  {utc(2024, time.March, 11, 3, 30), false}, // 23:30 EDT on the 10th
+				"next midnight": {utc(2024, time.March, 11, 4, 30), false}, // 00:30 EDT on the 11th
+			},
+			checks: []check{
+				{utc(2024, time.March, 10, 16, 0), domain.DueToday, []string{"day", "late evening"}},
+				{utc(2024, time.March, 10, 16, 0), domain.DueOverdue, []string{"day before"}},
+				{utc(2024, time.March, 10, 16, 0), domain.DueUpcoming, []string{"day after", "next midnight"}},
+				{utc(2024, time.March, 11, 3, 59), domain.DueToday, []string{"day", "late evening"}},
+				{utc(2024, time.March, 11, 3, 59), domain.DueOverdue, []string{"day before", "late evening"}},
+				{utc(2024, time.March, 11, 4, 0), domain.DueToday, []string{"day after", "next midnight"}},
+				{utc(2024, time.March, 11, 4, 0), domain.DueOverdue, []string{"day", "day before", "late evening"}},
+				{utc(2024, time.March, 11, 4, 0), domain.DueUpcoming, nil},
+			},
+		},
+		{
+			name:     "new york fall back",
+			timezone: "America/New_York",
+			todos: map[string]todo{
+				"day":           {allDay(2024, time.November, 3), true},
+				"late evening":  {utc(2024, time.November, 4, 4, 30), false}, // 23:30 EST on the 3rd
+				"next midnight": {utc(2024, time.November, 4, 5, 30), false}, // 00:30 EST on the 4th
+			},
+			checks: []check{
+				{utc(2024, time.November, 4, 4, 59), domain.DueToday, []string{"day", "late evening"}},
+				{utc(2024, time.November, 4, 4, 59), domain.DueOverdue, []string{"late evening"}},
+				{utc(2024, time.November, 4, 4, 59), domain.DueUpcoming, []string{"next midnight"}},
+				{utc(2024, time.November, 4, 5, 0), domain.DueToday, []string{"next midnight"}},
+				{utc(2024, time.November, 4, 5, 0), domain.DueOverdue, []string{"day", "late evening"}},
+			},
+		},
+		{
+			name:     "sydney fall back",
+			timezone: "Australia/Sydney",
+			todos: map[string]todo{
+				"day":           {allDay(2024, time.April, 7), true},
+				"late evening":  {utc(2024, time.April, 7, 13, 30), false}, // 23:30 AEST on the 7th
+				"next midnight": {utc(2024, time.April, 7, 14, 30), false}, // 00:30 AEST on the 8th
+			},
+			checks: []check{
+				{utc(2024, time.April, 7, 13, 59), domain.DueToday, []string{"day", "late evening"}},
+				{utc(2024, time.April, 7, 13, 59), domain.DueOverdue, []string{"late evening"}},
+				{utc(2024, time.April, 7, 14, 0), domain.DueToday, []string{"next midnight"}},
+				{utc(2024, time.April, 7, 14, 0), domain.DueOverdue, []string{"day", "late evening"}},
+			},
+		},
+		{
+			name:     "sydney spring forward",
+			timezone: "Australia/Sydney",
+			todos: map[string]todo{
+				"day":           {allDay(2024, time.October, 6), true},
+				"day after":     {allDay(2024, time.October, 7), true},
+				"late evening":  {utc(2024, time.October, 6, 12, 30), false}, // 23:30 AEDT on the 6th
+				"next midnight": {utc(2024, time.October, 6, 13, 30), false}, // 00:30 AEDT on the 7th
+			},
+			checks: []check{
+				// Still the 6th in UTC, but already the 7th in Sydney.
+				{utc(2024, time.October, 6, 12, 59), domain.DueToday, []string{"day", "late evening"}},
+				{utc(2024, time.October, 6, 12, 59), domain.DueUpcoming, []string{"day after", "next midnight"}},
+				{utc(2024, time.October, 6, 13, 0), domain.DueToday, []string{"day after", "next midnight"}},
+				{utc(2024, time.October, 6, 13, 0), domain.DueOverdue, []string{"day", "late evening"}},
+				{utc(2024, time.October, 6, 13, 0), domain.DueUpcoming, nil},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := insertUser(t, ctx, pool, tt.timezone)
+			names := map[int64]string{}
+			for name, td := range tt.todos {
+				names[insertTodo(t, ctx, pool, userID, nil, td.date, td.allDay)] = name
+			}
+
+			for _, c := range tt.checks {
+				where, params := filterTodos(userID, domain.TodoFilter{Due: c.due}, c.now)
+
+				rows, err := db(ctx, pool).Query(ctx, `SELECT id FROM todos`+where, params...)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var got []string
+				for rows.Next() {
+					var id int64
+					if err = rows.Scan(&id); err != nil {
+						t.Fatal(err)
+					}
+					got = append(got, names[id])
+				}
+				rows.Close()
+				if err = rows.Err(); err != nil {
+					t.Fatal(err)
+				}
+
+				slices.Sort(got)
+				slices.Sort(c.want)
+				if !slices.Equal(got, c.want) {
+					t.Errorf("due=%s at %s = %q, want %q", c.due, c.now.Format(time.RFC3339), got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLockOverdueAllDay(t *testing.T) {
+	ctx, pool := testContext(t)
+	repo := NewTodoRepository(pool)
+
+	tests := []struct {
+		name     string
+		timezone string
+		day      time.Time
+		// midnight is the end of the day in the creator's zone.
+		midnight time.Time
+	}{
+		{"new york spring forward", "America/New_York", allDay(2024, time.March, 10), utc(2024, time.March, 11, 4, 0)},
+		{"new york fall back", "America/New_York", allDay(2024, time.November, 3), utc(2024, time.November, 4, 5, 0)},
+		{"sydney fall back", "Australia/Sydney", allDay(2024, time.April, 7), utc(2024, time.April, 7, 14, 0)},
+		{"sydney spring forward", "Australia/Sydney", allDay(2024, time.October, 6), utc(2024, time.October, 6, 13, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := insertUser(t, ctx, pool, tt.timezone)
+			id := insertTodo(t, ctx, pool, userID, nil, tt.day, true)
+
+			for _, c := range []struct {
+				now  time.Time
+				want bool
+			}{
+				{tt.midnight.Add(-time.Second), false},
+				{tt.midnight, true},
+			} {
+				todos, err := repo.LockOverdue(ctx, c.now, 1<<30)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got := false
+				for _, td := range todos {
+					got = got || td.ID == id
+				}
+				if got != c.want {
+					t.Errorf("overdue at %s = %v, want %v", c.now.Format(time.RFC3339), got, c.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+// querier is the subset shared by *pgxpool.Pool and pgx.Tx that the
+// repositories need, so a query runs the same way in and out of a transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// db returns the transaction carried by ctx, falling back to the pool.
+func db(ctx context.Context, pool *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	return pool
+}
+
+type Transactor struct {
+	Conn *pgxpool.Pool
+}
+
+func NewTransactor(conn *pgxpool.Pool) *Transactor {
+	return &Transactor{
+		Conn: conn,
+	}
+}
+
+// WithinTransaction runs fn inside a transaction that every repository call
+// made with the ctx passed to fn takes part in. The transaction is committed
+// when fn returns nil and rolled back otherwise. Calls nested inside another
+// transaction run in a savepoint, so an inner failure can be recovered from
+// without aborting the outer transaction.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	var tx pgx.Tx
+	if outer, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		tx, err = outer.Begin(ctx)
+	} else {
+		tx, err = t.Conn.Begin(ctx)
+	}
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return
+		}
+
+		err = tx.Commit(ctx)
+	}()
+
+	return fn(context.WithValue(ctx, txKey{}, tx))
+}
@@ -2,8 +2,10 @@ package psql
 
 import (
 	"context"
+	"time"
 
 	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -18,7 +20,7 @@ func NewUserRepository(conn *pgxpool.Pool) *UserRepository {
 }
 
 func (u *UserRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.User, err error) {
-	rows, err := u.Conn.Query(ctx, query, args...)
+	rows, err := db(ctx, u.Conn).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +84,7 @@ func (u *UserRepository) GetByUsername(ctx context.Context, email string) (res d
 func (u *UserRepository) Register(ctx context.Context, user domain.User) (err error) {
 	query := `INSERT INTO users (username, password, name, updated_at, created_at) VALUES ($1, $2, $3, $4, $5)`
 
-	_, err = u.Conn.Exec(ctx, query, user.Username, user.Password, user.Name, user.UpdatedAt, user.CreatedAt)
+	_, err = db(ctx, u.Conn).Exec(ctx, query, user.Username, user.Password, user.Name, user.UpdatedAt, user.CreatedAt)
 	if err != nil {
 		return
 	}
@@ -106,3 +108,80 @@ func (u *UserRepository) GetByID(ctx context.Context, id int64) (res domain.User
 
 	return
 }
+
+func (u *UserRepository) GetSettings(ctx context.Context, id int64) (res domain.UserSettings, err error) {
+	query := `SELECT auto_archive_days, email, timezone FROM users WHERE id=$1`
+
+	err = db(ctx, u.Conn).QueryRow(ctx, query, id).Scan(&res.AutoArchiveDays, &res.Email, &res.Timezone)
+	if err == pgx.ErrNoRows {
+		return domain.UserSettings{}, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (u *UserRepository) UpdateSettings(ctx context.Context, id int64, settings domain.UserSettings, updatedAt time.Time) (err error) {
+	query := `UPDATE users SET auto_archive_days=$1, email=$2, timezone=$3, updated_at=$4 WHERE id=$5`
+
+	commandTag, err := db(ctx, u.Conn).Exec(ctx, query, settings.AutoArchiveDays, settings.Email, settings.Timezone, updatedAt, id)
+	if err != nil {
+		return
+	}
+
+	if commandTag.RowsAffected() != 1 {
+		return domain.ErrNotFound
+	}
+
+	return
+}
+
+func (u *UserRepository) GetByUsernames(ctx context.Context, usernames []string) (res []domain.User, err error) {
+	query := `SELECT id, username, password, name, updated_at, created_at FROM users WHERE username = ANY($1)`
+
+	return u.fetch(ctx, query, usernames)
+}
+
+func (u *UserRepository) GetByCalendarToken(ctx context.Context, token string) (res domain.User, err error) {
+	query := `SELECT id, username, password, name, updated_at, created_at FROM users WHERE calendar_token=$1`
+
+	list, err := u.fetch(ctx, query, token)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return domain.User{}, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (u *UserRepository) GetCalendarToken(ctx context.Context, id int64) (res *string, err error) {
+	query := `SELECT calendar_token FROM users WHERE id=$1`
+
+	err = db(ctx, u.Conn).QueryRow(ctx, query, id).Scan(&res)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+
+	return
+}
+
+// SetCalendarToken replaces the user's calendar feed token. A nil token turns
+// the feed off.
+func (u *UserRepository) SetCalendarToken(ctx context.Context, id int64, token *string, updatedAt time.Time) (err error) {
+	query := `UPDATE users SET calendar_token=$1, updated_at=$2 WHERE id=$3`
+
+	commandTag, err := db(ctx, u.Conn).Exec(ctx, query, token, updatedAt, id)
+	if err != nil {
+		return
+	}
+
+	if commandTag.RowsAffected() != 1 {
+		return domain.ErrNotFound
+	}
+
+	return
+}
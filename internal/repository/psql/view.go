@@ -0,0 +1,117 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const selectView = `SELECT id, user_id, name, filter, updated_at, created_at FROM saved_views`
+
+type ViewRepository struct {
+	Conn *pgxpool.Pool
+}
+
+func NewViewRepository(conn *pgxpool.Pool) *ViewRepository {
+	return &ViewRepository{
+		Conn: conn,
+	}
+}
+
+func (v *ViewRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.SavedView, err error) {
+	rows, err := db(ctx, v.Conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		sv := domain.SavedView{}
+		err = rows.Scan(
+			&sv.ID,
+			&sv.UserID,
+			&sv.Name,
+			&sv.Filter,
+			&sv.UpdatedAt,
+			&sv.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, sv)
+	}
+
+	return
+}
+
+func (v *ViewRepository) GetByUserID(ctx context.Context, userID int64) (res []domain.SavedView, err error) {
+	query := selectView + ` WHERE user_id = $1 ORDER BY name, id`
+
+	res, err = v.fetch(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (v *ViewRepository) GetByID(ctx context.Context, id int64) (res domain.SavedView, err error) {
+	query := selectView + ` WHERE id = $1`
+
+	list, err := v.fetch(ctx, query, id)
+	if err != nil {
+		return domain.SavedView{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (v *ViewRepository) Store(ctx context.Context, sv *domain.SavedView) (err error) {
+	query := `INSERT INTO saved_views (user_id, name, filter, updated_at, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+
+	return db(ctx, v.Conn).QueryRow(ctx, query, sv.UserID, sv.Name, sv.Filter, sv.UpdatedAt, sv.CreatedAt).Scan(&sv.ID)
+}
+
+func (v *ViewRepository) Update(ctx context.Context, sv *domain.SavedView) (err error) {
+	query := `UPDATE saved_views SET name = $1, filter = $2, updated_at = $3 WHERE id = $4`
+
+	commandTag, err := db(ctx, v.Conn).Exec(ctx, query, sv.Name, sv.Filter, sv.UpdatedAt, sv.ID)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (v *ViewRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := `DELETE FROM saved_views WHERE id = $1`
+
+	commandTag, err := db(ctx, v.Conn).Exec(ctx, query, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
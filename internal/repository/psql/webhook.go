@@ -0,0 +1,283 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/abrahammegantoro/to-do-list-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const selectWebhook = `SELECT id, user_id, url, secret, events, active, updated_at, created_at FROM webhooks`
+
+type WebhookRepository struct {
+	Conn *pgxpool.Pool
+}
+
+func NewWebhookRepository(conn *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{
+		Conn: conn,
+	}
+}
+
+func (w *WebhookRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Webhook, err error) {
+	rows, err := db(ctx, w.Conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		wh := domain.Webhook{}
+		err = rows.Scan(
+			&wh.ID,
+			&wh.UserID,
+			&wh.URL,
+			&wh.Secret,
+			&wh.Events,
+			&wh.Active,
+			&wh.UpdatedAt,
+			&wh.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, wh)
+	}
+
+	return
+}
+
+func (w *WebhookRepository) GetByUserID(ctx context.Context, userID int64) (res []domain.Webhook, err error) {
+	query := selectWebhook + ` WHERE user_id = $1 ORDER BY id`
+
+	res, err = w.fetch(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (w *WebhookRepository) GetByID(ctx context.Context, id int64) (res domain.Webhook, err error) {
+	query := selectWebhook + ` WHERE id = $1`
+
+	list, err := w.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (w *WebhookRepository) Store(ctx context.Context, wh *domain.Webhook) (err error) {
+	query := `INSERT INTO webhooks (user_id, url, secret, events, active, updated_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	return db(ctx, w.Conn).QueryRow(ctx, query, wh.UserID, wh.URL, wh.Secret, wh.Events, wh.Active, wh.UpdatedAt, wh.CreatedAt).Scan(&wh.ID)
+}
+
+func (w *WebhookRepository) Update(ctx context.Context, wh *domain.Webhook) (err error) {
+	query := `UPDATE webhooks SET url = $1, secret = $2, events = $3, active = $4, updated_at = $5 WHERE id = $6`
+
+	commandTag, err := db(ctx, w.Conn).Exec(ctx, query, wh.URL, wh.Secret, wh.Events, wh.Active, wh.UpdatedAt, wh.ID)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (w *WebhookRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := `DELETE FROM webhooks WHERE id = $1`
+
+	commandTag, err := db(ctx, w.Conn).Exec(ctx, query, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected := commandTag.RowsAffected()
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+// Enqueue adds the payload to the outbox of every active webhook of the
+// given users that subscribes to its event. Call it in the transaction that
+// makes the change the payload describes.
+func (w *WebhookRepository) Enqueue(ctx context.Context, userIDs []int64, payload domain.WebhookPayload) (err error) {
+	query := `INSERT INTO webhook_outbox (webhook_id, event, payload, next_attempt_at, created_at)
+		SELECT id, $2::VARCHAR, $3::JSONB, $4::TIMESTAMPTZ, $4 FROM webhooks WHERE user_id = ANY($1) AND active AND $2 = ANY(events)`
+
+	_, err = db(ctx, w.Conn).Exec(ctx, query, userIDs, payload.Event, payload, payload.CreatedAt)
+	return
+}
+
+// ClaimDue takes up to limit pending messages whose next attempt is due by
+// moving their next attempt to leaseUntil, and returns them. Until the lease
+// runs out other dispatchers leave the messages alone, so they can be sent
+// without holding a transaction open; a message whose dispatcher died is
+// picked up again once it has. Messages claimed concurrently are skipped.
+func (w *WebhookRepository) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int64) (res []domain.WebhookMessage, err error) {
+	query := `UPDATE webhook_outbox o SET next_attempt_at = $2
+		FROM webhooks w
+		WHERE w.id = o.webhook_id AND o.id IN (
+			SELECT d.id FROM webhook_outbox d JOIN webhooks dw ON dw.id = d.webhook_id
+			WHERE d.delivered_at IS NULL AND d.failed_at IS NULL AND d.next_attempt_at <= $1 AND dw.active
+			ORDER BY d.next_attempt_at LIMIT $3 FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING o.id, o.webhook_id, w.url, w.secret, o.event, o.payload, o.attempts`
+
+	rows, err := db(ctx, w.Conn).Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		msg := domain.WebhookMessage{}
+		err = rows.Scan(&msg.ID, &msg.WebhookID, &msg.URL, &msg.Secret, &msg.Event, &msg.Payload, &msg.Attempts)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, msg)
+	}
+
+	return
+}
+
+func (w *WebhookRepository) MarkDelivered(ctx context.Context, id int64, attempts int, deliveredAt time.Time) (err error) {
+	query := `UPDATE webhook_outbox SET attempts = $1, delivered_at = $2 WHERE id = $3`
+
+	_, err = db(ctx, w.Conn).Exec(ctx, query, attempts, deliveredAt, id)
+	return
+}
+
+func (w *WebhookRepository) ScheduleRetry(ctx context.Context, id int64, attempts int, nextAttemptAt time.Time) (err error) {
+	query := `UPDATE webhook_outbox SET attempts = $1, next_attempt_at = $2 WHERE id = $3`
+
+	_, err = db(ctx, w.Conn).Exec(ctx, query, attempts, nextAttemptAt, id)
+	return
+}
+
+func (w *WebhookRepository) MarkFailed(ctx context.Context, id int64, attempts int, failedAt time.Time) (err error) {
+	query := `UPDATE webhook_outbox SET attempts = $1, failed_at = $2 WHERE id = $3`
+
+	_, err = db(ctx, w.Conn).Exec(ctx, query, attempts, failedAt, id)
+	return
+}
+
+// Requeue copies a message back into the outbox as a new pending message.
+func (w *WebhookRepository) Requeue(ctx context.Context, messageID int64, now time.Time) (res int64, err error) {
+	query := `INSERT INTO webhook_outbox (webhook_id, event, payload, next_attempt_at, created_at)
+		SELECT webhook_id, event, payload, $2, $2 FROM webhook_outbox WHERE id = $1 RETURNING id`
+
+	err = db(ctx, w.Conn).QueryRow(ctx, query, messageID, now).Scan(&res)
+	return
+}
+
+func (w *WebhookRepository) StoreDelivery(ctx context.Context, d *domain.WebhookDelivery) (err error) {
+	query := `INSERT INTO webhook_deliveries (message_id, webhook_id, attempt, status_code, error, duration_ms, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	return db(ctx, w.Conn).QueryRow(ctx, query, d.MessageID, d.WebhookID, d.Attempt, d.StatusCode, d.Error, d.DurationMs, d.CreatedAt).Scan(&d.ID)
+}
+
+func (w *WebhookRepository) fetchDeliveries(ctx context.Context, query string, args ...interface{}) (result []domain.WebhookDelivery, err error) {
+	rows, err := db(ctx, w.Conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		d := domain.WebhookDelivery{}
+		err = rows.Scan(
+			&d.ID,
+			&d.MessageID,
+			&d.WebhookID,
+			&d.Event,
+			&d.Attempt,
+			&d.StatusCode,
+			&d.Error,
+			&d.DurationMs,
+			&d.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, d)
+	}
+
+	return
+}
+
+// GetDeliveries returns the delivery log of a webhook, newest first.
+func (w *WebhookRepository) GetDeliveries(ctx context.Context, webhookID int64, cursor string, num int64) (res []domain.WebhookDelivery, nextCursor string, err error) {
+	query := `SELECT d.id, d.message_id, d.webhook_id, o.event, d.attempt, d.status_code, d.error, d.duration_ms, d.created_at
+		FROM webhook_deliveries d JOIN webhook_outbox o ON o.id = d.message_id
+		WHERE d.webhook_id = $1 AND ($2::TIMESTAMPTZ IS NULL OR (d.created_at, d.id) < ($2, $3::BIGINT))
+		ORDER BY d.created_at DESC, d.id DESC LIMIT $4`
+
+	var before *time.Time
+	var beforeID *int64
+	if cursor != "" {
+		createdAt, id, err := repository.DecodeKeyCursor(cursor)
+		if err != nil {
+			return nil, "", domain.ErrBadParamInput
+		}
+		before, beforeID = &createdAt, &id
+	}
+
+	res, err = w.fetchDeliveries(ctx, query, webhookID, before, beforeID, num)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(res) == int(num) {
+		last := res[len(res)-1]
+		nextCursor = repository.EncodeKeyCursor(last.CreatedAt, last.ID)
+	}
+
+	return
+}
+
+func (w *WebhookRepository) GetDeliveryByID(ctx context.Context, id int64) (res domain.WebhookDelivery, err error) {
+	query := `SELECT d.id, d.message_id, d.webhook_id, o.event, d.attempt, d.status_code, d.error, d.duration_ms, d.created_at
+		FROM webhook_deliveries d JOIN webhook_outbox o ON o.id = d.message_id
+		WHERE d.id = $1`
+
+	list, err := w.fetchDeliveries(ctx, query, id)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type ActivityService interface {
+	Activity(ctx context.Context, userID int64, cursor string, num int64) ([]domain.TodoEvent, string, error)
+}
+
+type ActivityHandler struct {
+	Service ActivityService
+}
+
+func NewActivityHandler(e *echo.Group, svc ActivityService) {
+	handler := &ActivityHandler{
+		Service: svc,
+	}
+
+	e.GET("/activity", handler.Activity)
+}
+
+func (a *ActivityHandler) Activity(c echo.Context) error {
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit == 0 {
+		limit = defaultLimit
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	events, nextCursor, err := a.Service.Activity(ctx, userId, c.QueryParam("cursor"), int64(limit))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":      http.StatusOK,
+		"message":     "success",
+		"data":        events,
+		"next_cursor": nextCursor,
+	})
+}
@@ -0,0 +1,197 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type AttachmentService interface {
+	MaxSize() int64
+	GetByTodoID(ctx context.Context, userID int64, todoID int64) ([]domain.Attachment, error)
+	Upload(ctx context.Context, userID int64, todoID int64, filename string, r io.Reader) (domain.Attachment, error)
+	Download(ctx context.Context, userID int64, todoID int64, id int64) (domain.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, userID int64, todoID int64, id int64) error
+}
+
+type AttachmentHandler struct {
+	Service AttachmentService
+}
+
+// multipartOverhead leaves room for the multipart boundaries and headers on
+// top of the file size limit when capping the request body.
+const multipartOverhead = 1 << 20
+
+func NewAttachmentHandler(e *echo.Group, svc AttachmentService) {
+	handler := &AttachmentHandler{
+		Service: svc,
+	}
+
+	e.GET("/:id/attachments", handler.GetByTodoID)
+	e.POST("/:id/attachments", handler.Upload)
+	e.GET("/:id/attachments/:attachmentId", handler.Download)
+	e.DELETE("/:id/attachments/:attachmentId", handler.Delete)
+}
+
+func (a *AttachmentHandler) GetByTodoID(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	list, err := a.Service.GetByTodoID(ctx, userId, todoID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    list,
+	})
+}
+
+func (a *AttachmentHandler) Upload(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, a.Service.MaxSize()+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+	}
+	if fileHeader.Size > a.Service.MaxSize() {
+		return c.JSON(getStatusCode(domain.ErrFileTooLarge), map[string]interface{}{
+			"status":  getStatusCode(domain.ErrFileTooLarge),
+			"message": domain.ErrFileTooLarge.Error(),
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+	}
+	defer file.Close()
+
+	ctx := req.Context()
+	at, err := a.Service.Upload(ctx, userId, todoID, fileHeader.Filename, file)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status":  http.StatusCreated,
+		"message": "success",
+		"data":    at,
+	})
+}
+
+func (a *AttachmentHandler) Download(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	id, err := strconv.ParseInt(c.Param("attachmentId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	at, body, err := a.Service.Download(ctx, userId, todoID, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+	defer body.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": at.Filename}))
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(at.Size, 10))
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+
+	return c.Stream(http.StatusOK, at.ContentType, body)
+}
+
+func (a *AttachmentHandler) Delete(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	id, err := strconv.ParseInt(c.Param("attachmentId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = a.Service.Delete(ctx, userId, todoID, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "attachment successfully deleted",
+	})
+}
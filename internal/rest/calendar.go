@@ -0,0 +1,153 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/abrahammegantoro/to-do-list-be/calendar"
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type CalendarService interface {
+	Feed(ctx context.Context, token string) ([]domain.Todo, error)
+	GetToken(ctx context.Context, userID int64) (string, error)
+	RegenerateToken(ctx context.Context, userID int64) (string, error)
+	RevokeToken(ctx context.Context, userID int64) error
+}
+
+type CalendarHandler struct {
+	Service CalendarService
+}
+
+// NewCalendarHandler serves the calendar feed. The group must not require a
+// login; the token in the URL identifies the user.
+func NewCalendarHandler(e *echo.Group, svc CalendarService) {
+	handler := &CalendarHandler{
+		Service: svc,
+	}
+
+	e.GET("/:file", handler.Feed)
+}
+
+// NewCalendarTokenHandler lets the authenticated user manage their feed URL.
+func NewCalendarTokenHandler(e *echo.Group, svc CalendarService) {
+	handler := &CalendarHandler{
+		Service: svc,
+	}
+
+	e.GET("/calendar", handler.GetToken)
+	e.POST("/calendar/token", handler.RegenerateToken)
+	e.DELETE("/calendar/token", handler.RevokeToken)
+}
+
+func (ch *CalendarHandler) Feed(c echo.Context) error {
+	token, ok := strings.CutSuffix(c.Param("file"), ".ics")
+	if !ok || token == "" {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  http.StatusNotFound,
+			"message": domain.ErrNotFound.Error(),
+		})
+	}
+
+	component := calendar.Component(c.QueryParam("component"))
+	switch component {
+	case "":
+		component = calendar.ComponentEvent
+	case calendar.ComponentEvent, calendar.ComponentTodo:
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": domain.ErrBadParamInput.Error(),
+		})
+	}
+
+	ctx := c.Request().Context()
+	todos, err := ch.Service.Feed(ctx, token)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/calendar; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, `inline; filename="todos.ics"`)
+	res.WriteHeader(http.StatusOK)
+
+	if err = calendar.WriteICS(res, todos, component, c.Request().Host); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+func (ch *CalendarHandler) GetToken(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	token, err := ch.Service.GetToken(ctx, userId)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    calendarFeed(c, token),
+	})
+}
+
+func (ch *CalendarHandler) RegenerateToken(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	token, err := ch.Service.RegenerateToken(ctx, userId)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    calendarFeed(c, token),
+	})
+}
+
+func (ch *CalendarHandler) RevokeToken(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err := ch.Service.RevokeToken(ctx, userId)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "calendar feed successfully disabled",
+	})
+}
+
+func calendarFeed(c echo.Context, token string) map[string]interface{} {
+	return map[string]interface{}{
+		"token": token,
+		"url":   c.Scheme() + "://" + c.Request().Host + "/api/v1/calendar/" + token + ".ics",
+	}
+}
@@ -0,0 +1,205 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type CommentService interface {
+	GetByTodoID(ctx context.Context, userID int64, todoID int64, cursor string, num int64) ([]domain.Comment, string, error)
+	Store(ctx context.Context, userID int64, todoID int64, c *domain.Comment) error
+	Update(ctx context.Context, userID int64, todoID int64, c *domain.Comment) error
+	Delete(ctx context.Context, userID int64, todoID int64, id int64) error
+}
+
+type CommentHandler struct {
+	Service CommentService
+}
+
+func NewCommentHandler(e *echo.Group, svc CommentService) {
+	handler := &CommentHandler{
+		Service: svc,
+	}
+
+	e.GET("/:id/comments", handler.GetByTodoID)
+	e.POST("/:id/comments", handler.Store)
+	e.PATCH("/:id/comments/:commentId", handler.Update)
+	e.DELETE("/:id/comments/:commentId", handler.Delete)
+}
+
+func (cm *CommentHandler) GetByTodoID(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit == 0 {
+		limit = defaultLimit
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	list, nextCursor, err := cm.Service.GetByTodoID(ctx, userId, todoID, c.QueryParam("cursor"), int64(limit))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":      http.StatusOK,
+		"message":     "success",
+		"data":        list,
+		"next_cursor": nextCursor,
+	})
+}
+
+func (cm *CommentHandler) Store(c echo.Context) (err error) {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var comment domain.Comment
+	err = c.Bind(&comment)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&comment); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = cm.Service.Store(ctx, userId, todoID, &comment)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status":  http.StatusCreated,
+		"message": "success",
+		"data":    comment,
+	})
+}
+
+func (cm *CommentHandler) Update(c echo.Context) (err error) {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	commentID, err := strconv.ParseInt(c.Param("commentId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var comment domain.Comment
+	err = c.Bind(&comment)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&comment); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	comment.ID = commentID
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = cm.Service.Update(ctx, userId, todoID, &comment)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    comment,
+	})
+}
+
+func (cm *CommentHandler) Delete(c echo.Context) (err error) {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	commentID, err := strconv.ParseInt(c.Param("commentId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = cm.Service.Delete(ctx, userId, todoID, commentID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "comment successfully deleted",
+	})
+}
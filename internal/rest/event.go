@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type EventSubscriber interface {
+	Subscribe(userID int64) (<-chan domain.StreamEvent, func())
+}
+
+type EventHandler struct {
+	Subscriber     EventSubscriber
+	OriginPatterns []string
+}
+
+const (
+	// heartbeatInterval keeps idle streams from being closed by proxies.
+	heartbeatInterval = 30 * time.Second
+	writeTimeout      = 10 * time.Second
+)
+
+// NewEventHandler serves the event stream as Server-Sent Events, or over a
+// WebSocket when the request asks for an upgrade. originPatterns lists the
+// hosts other than our own that may open a WebSocket.
+func NewEventHandler(e *echo.Group, sub EventSubscriber, originPatterns []string) {
+	handler := &EventHandler{
+		Subscriber:     sub,
+		OriginPatterns: originPatterns,
+	}
+
+	e.GET("", handler.Stream)
+}
+
+func (h *EventHandler) Stream(c echo.Context) error {
+	if strings.EqualFold(c.Request().Header.Get("Upgrade"), "websocket") {
+		return h.webSocket(c)
+	}
+
+	return h.serverSentEvents(c)
+}
+
+func (h *EventHandler) serverSentEvents(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	events, unsubscribe := h.Subscriber.Subscribe(userId)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+		case ev, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			data, err := json.Marshal(ev)
+			if err != nil {
+				logrus.Error(err)
+				continue
+			}
+
+			if _, err = fmt.Fprintf(res, "event: %s\ndata: %s\n\n", ev.Type, data); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
+func (h *EventHandler) webSocket(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+
+	conn, err := websocket.Accept(c.Response(), c.Request(), &websocket.AcceptOptions{
+		OriginPatterns: h.OriginPatterns,
+	})
+	if err != nil {
+		logrus.Error(err)
+		return nil
+	}
+	defer conn.CloseNow()
+
+	events, unsubscribe := h.Subscriber.Subscribe(userId)
+	defer unsubscribe()
+
+	// The stream is one-way; CloseRead handles pings and notices the client
+	// going away.
+	ctx := conn.CloseRead(c.Request().Context())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-events:
+			if !ok {
+				conn.Close(websocket.StatusGoingAway, "")
+				return nil
+			}
+
+			if err = h.write(ctx, conn, ev); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+func (h *EventHandler) write(ctx context.Context, conn *websocket.Conn, ev domain.StreamEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+
+	return wsjson.Write(ctx, conn, ev)
+}
@@ -28,8 +28,24 @@ func getStatusCode(err error) int {
 		return http.StatusUnauthorized
 	case domain.ErrBadParamInput:
 		return http.StatusBadRequest
+	case domain.ErrForbidden:
+		return http.StatusForbidden
+	case domain.ErrLastOwner:
+		return http.StatusConflict
+	case domain.ErrAssigneeAccess:
+		return http.StatusUnprocessableEntity
 	case domain.ErrUsernameTaken:
 		return http.StatusConflict
+	case domain.ErrFileTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case domain.ErrUnsupportedMedia:
+		return http.StatusUnsupportedMediaType
+	case domain.ErrBatchAborted:
+		return http.StatusUnprocessableEntity
+	case domain.ErrDependencyCycle:
+		return http.StatusConflict
+	case domain.ErrWebhookAddress:
+		return http.StatusBadRequest
 	default:
 		return http.StatusInternalServerError
 	}
@@ -53,6 +69,81 @@ func isRequestValid(v interface{}) (bool, error) {
 		if err != nil {
 			return false, err
 		}
+	case *domain.UserSettings:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.List:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.ListMember:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.ListInvitation:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.ReminderRequest:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.Webhook:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.Comment:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.AssignRequest:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.TimeEntry:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.DependencyRequest:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.Template:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.SavedView:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.TodoFilter:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.QuickAddRequest:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
+	case *domain.BatchRequest:
+		err := validate.Struct(v)
+		if err != nil {
+			return false, err
+		}
 	default:
 		return false, errors.New("unsupported type")
 	}
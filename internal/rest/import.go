@@ -0,0 +1,119 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/abrahammegantoro/to-do-list-be/internal/importer"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type ImportService interface {
+	Import(ctx context.Context, userID int64, listID *int64, todos []domain.Todo, dryRun bool) (int64, error)
+}
+
+type ImportHandler struct {
+	Service ImportService
+	MaxSize int64
+}
+
+// NewImportHandler accepts import files of up to maxSize bytes.
+func NewImportHandler(e *echo.Group, svc ImportService, maxSize int64) {
+	handler := &ImportHandler{
+		Service: svc,
+		MaxSize: maxSize,
+	}
+
+	e.POST("/import", handler.Import)
+}
+
+// Import reads the file in the request body and checks every record with the
+// rules of the todo endpoints. Nothing is stored while a record is invalid,
+// unless skip_invalid is set, nor when dry_run is set.
+func (i *ImportHandler) Import(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+
+	format := importer.Format(c.QueryParam("format"))
+	dryRun, _ := strconv.ParseBool(c.QueryParam("dry_run"))
+	skipInvalid, _ := strconv.ParseBool(c.QueryParam("skip_invalid"))
+
+	var listID *int64
+	if id, err := strconv.ParseInt(c.QueryParam("list_id"), 10, 64); err == nil {
+		listID = &id
+	}
+
+	// column_<field>=<header> reads a field of a CSV import from another column.
+	mapping := map[string]string{}
+	for _, field := range importer.Fields {
+		if column := c.QueryParam("column_" + field); column != "" {
+			mapping[field] = column
+		}
+	}
+
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, i.MaxSize)
+
+	rows, err := importer.Parse(format, req.Body, mapping)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+	}
+
+	result := domain.ImportResult{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Errors: []domain.ImportRowError{},
+	}
+
+	todos := make([]domain.Todo, 0, len(rows))
+	for _, row := range rows {
+		if row.Err == nil {
+			// The owner is filled in here so the required check passes; the
+			// service sets it again when storing.
+			row.Todo.UserID = userId
+			_, row.Err = isRequestValid(&row.Todo)
+		}
+		if row.Err != nil {
+			result.Errors = append(result.Errors, domain.ImportRowError{Row: row.Number, Message: row.Err.Error()})
+			continue
+		}
+
+		todos = append(todos, row.Todo)
+	}
+	result.Valid = len(todos)
+
+	if len(result.Errors) > 0 && !skipInvalid && !dryRun {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  http.StatusUnprocessableEntity,
+			"message": "import has invalid rows",
+			"data":    result,
+		})
+	}
+
+	ctx := req.Context()
+	result.Imported, err = i.Service.Import(ctx, userId, listID, todos, dryRun)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+
+	return c.JSON(status, map[string]interface{}{
+		"status":  status,
+		"message": "success",
+		"data":    result,
+	})
+}
@@ -0,0 +1,365 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type ListService interface {
+	Fetch(ctx context.Context, userID int64) ([]domain.List, error)
+	GetByID(ctx context.Context, userID int64, id int64) (domain.List, error)
+	Store(ctx context.Context, userID int64, list *domain.List) error
+	Update(ctx context.Context, userID int64, list *domain.List) error
+	Delete(ctx context.Context, userID int64, id int64) error
+	GetMembers(ctx context.Context, userID int64, listID int64) ([]domain.ListMember, error)
+	AddMember(ctx context.Context, userID int64, listID int64, invitation *domain.ListInvitation) (domain.ListMember, error)
+	UpdateMemberRole(ctx context.Context, userID int64, listID int64, memberID int64, role domain.ListRole) error
+	RemoveMember(ctx context.Context, userID int64, listID int64, memberID int64) error
+}
+
+type ListHandler struct {
+	Service ListService
+}
+
+func NewListHandler(e *echo.Group, svc ListService) {
+	handler := &ListHandler{
+		Service: svc,
+	}
+
+	e.GET("", handler.Fetch)
+	e.POST("", handler.Store)
+	e.GET("/:id", handler.GetByID)
+	e.PUT("/:id", handler.Update)
+	e.DELETE("/:id", handler.Delete)
+	e.GET("/:id/members", handler.GetMembers)
+	e.POST("/:id/members", handler.AddMember)
+	e.PUT("/:id/members/:userId", handler.UpdateMemberRole)
+	e.DELETE("/:id/members/:userId", handler.RemoveMember)
+}
+
+func (l *ListHandler) Fetch(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	lists, err := l.Service.Fetch(ctx, userId)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    lists,
+	})
+}
+
+func (l *ListHandler) GetByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	list, err := l.Service.GetByID(ctx, userId, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    list,
+	})
+}
+
+func (l *ListHandler) Store(c echo.Context) (err error) {
+	userId := c.Get("userId").(int64)
+
+	var list domain.List
+	err = c.Bind(&list)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&list); !ok {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+	}
+
+	ctx := c.Request().Context()
+	err = l.Service.Store(ctx, userId, &list)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status":  http.StatusCreated,
+		"message": "success",
+		"data":    list,
+	})
+}
+
+func (l *ListHandler) Update(c echo.Context) (err error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+
+	var list domain.List
+	err = c.Bind(&list)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&list); !ok {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+	}
+
+	ctx := c.Request().Context()
+	list.ID = id
+	err = l.Service.Update(ctx, userId, &list)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    list,
+	})
+}
+
+func (l *ListHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = l.Service.Delete(ctx, userId, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "list successfully deleted",
+	})
+}
+
+func (l *ListHandler) GetMembers(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	members, err := l.Service.GetMembers(ctx, userId, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    members,
+	})
+}
+
+func (l *ListHandler) AddMember(c echo.Context) (err error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+
+	var invitation domain.ListInvitation
+	err = c.Bind(&invitation)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&invitation); !ok {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+	}
+
+	ctx := c.Request().Context()
+	member, err := l.Service.AddMember(ctx, userId, id, &invitation)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status":  http.StatusCreated,
+		"message": "success",
+		"data":    member,
+	})
+}
+
+func (l *ListHandler) UpdateMemberRole(c echo.Context) (err error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	memberID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+
+	var member domain.ListMember
+	err = c.Bind(&member)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&member); !ok {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+	}
+
+	ctx := c.Request().Context()
+	err = l.Service.UpdateMemberRole(ctx, userId, id, memberID, member.Role)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+	})
+}
+
+func (l *ListHandler) RemoveMember(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	memberID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = l.Service.RemoveMember(ctx, userId, id, memberID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "member successfully removed",
+	})
+}
@@ -56,3 +56,17 @@ func AuthMiddleware(user UserRepository) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// QueryToken lets clients that cannot set request headers, such as the
+// browser EventSource and WebSocket APIs, pass their token in the
+// access_token query parameter. It must run before AuthMiddleware.
+func QueryToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		if token := c.QueryParam("access_token"); token != "" && req.Header.Get("Authorization") == "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		return next(c)
+	}
+}
@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type NotificationService interface {
+	Fetch(ctx context.Context, userID int64, unread bool, cursor string, num int64) ([]domain.Notification, string, error)
+	UnreadCount(ctx context.Context, userID int64) (int64, error)
+	MarkRead(ctx context.Context, userID int64, id int64) error
+	MarkAllRead(ctx context.Context, userID int64) (int64, error)
+}
+
+type NotificationHandler struct {
+	Service NotificationService
+}
+
+func NewNotificationHandler(e *echo.Group, svc NotificationService) {
+	handler := &NotificationHandler{
+		Service: svc,
+	}
+
+	e.GET("/notifications", handler.Fetch)
+	e.GET("/notifications/unread-count", handler.UnreadCount)
+	e.POST("/notifications/:id/read", handler.MarkRead)
+	e.POST("/notifications/read-all", handler.MarkAllRead)
+}
+
+func (n *NotificationHandler) Fetch(c echo.Context) error {
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit == 0 {
+		limit = defaultLimit
+	}
+
+	unread, _ := strconv.ParseBool(c.QueryParam("unread"))
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	notifications, nextCursor, err := n.Service.Fetch(ctx, userId, unread, c.QueryParam("cursor"), int64(limit))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":      http.StatusOK,
+		"message":     "success",
+		"data":        notifications,
+		"next_cursor": nextCursor,
+	})
+}
+
+func (n *NotificationHandler) UnreadCount(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	count, err := n.Service.UnreadCount(ctx, userId)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    map[string]interface{}{"unread": count},
+	})
+}
+
+func (n *NotificationHandler) MarkRead(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = n.Service.MarkRead(ctx, userId, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "notification marked as read",
+	})
+}
+
+func (n *NotificationHandler) MarkAllRead(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	count, err := n.Service.MarkAllRead(ctx, userId)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    map[string]interface{}{"marked": count},
+	})
+}
@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type ReminderService interface {
+	GetByTodoID(ctx context.Context, userID int64, todoID int64) ([]domain.Reminder, error)
+	Set(ctx context.Context, userID int64, todoID int64, req *domain.ReminderRequest) ([]domain.Reminder, error)
+}
+
+type ReminderHandler struct {
+	Service ReminderService
+}
+
+func NewReminderHandler(e *echo.Group, svc ReminderService) {
+	handler := &ReminderHandler{
+		Service: svc,
+	}
+
+	e.GET("/:id/reminders", handler.GetByTodoID)
+	e.PUT("/:id/reminders", handler.Set)
+}
+
+func (r *ReminderHandler) GetByTodoID(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	reminders, err := r.Service.GetByTodoID(ctx, userId, todoID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    reminders,
+	})
+}
+
+func (r *ReminderHandler) Set(c echo.Context) (err error) {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var req domain.ReminderRequest
+	err = c.Bind(&req)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&req); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	reminders, err := r.Service.Set(ctx, userId, todoID, &req)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    reminders,
+	})
+}
@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type SettingsService interface {
+	GetSettings(ctx context.Context, userID int64) (domain.UserSettings, error)
+	UpdateSettings(ctx context.Context, userID int64, settings *domain.UserSettings) error
+}
+
+type SettingsHandler struct {
+	Service SettingsService
+}
+
+func NewSettingsHandler(e *echo.Group, svc SettingsService) {
+	handler := &SettingsHandler{
+		Service: svc,
+	}
+
+	e.GET("/settings", handler.Get)
+	e.PUT("/settings", handler.Update)
+}
+
+func (s *SettingsHandler) Get(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	settings, err := s.Service.GetSettings(ctx, userId)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    settings,
+	})
+}
+
+func (s *SettingsHandler) Update(c echo.Context) (err error) {
+	userId := c.Get("userId").(int64)
+
+	var settings domain.UserSettings
+	err = c.Bind(&settings)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&settings); !ok {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+	}
+
+	ctx := c.Request().Context()
+	err = s.Service.UpdateSettings(ctx, userId, &settings)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    settings,
+	})
+}
@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type StatsService interface {
+	Stats(ctx context.Context, userID int64, from string, to string) (domain.Stats, error)
+}
+
+type StatsHandler struct {
+	Service StatsService
+}
+
+func NewStatsHandler(e *echo.Group, svc StatsService) {
+	handler := &StatsHandler{
+		Service: svc,
+	}
+
+	e.GET("/stats", handler.Stats)
+}
+
+func (s *StatsHandler) Stats(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	stats, err := s.Service.Stats(ctx, userId, c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    stats,
+	})
+}
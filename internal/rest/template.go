@@ -0,0 +1,234 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type TemplateService interface {
+	Fetch(ctx context.Context, userID int64) ([]domain.Template, error)
+	GetByID(ctx context.Context, userID int64, id int64) (domain.Template, error)
+	Store(ctx context.Context, userID int64, tpl *domain.Template) error
+	Update(ctx context.Context, userID int64, tpl *domain.Template) error
+	Delete(ctx context.Context, userID int64, id int64) error
+	Instantiate(ctx context.Context, userID int64, id int64, start string, listID *int64) ([]domain.Todo, error)
+}
+
+type TemplateHandler struct {
+	Service TemplateService
+}
+
+func NewTemplateHandler(e *echo.Group, svc TemplateService) {
+	handler := &TemplateHandler{
+		Service: svc,
+	}
+
+	e.GET("", handler.Fetch)
+	e.POST("", handler.Store)
+	e.GET("/:id", handler.GetByID)
+	e.PUT("/:id", handler.Update)
+	e.DELETE("/:id", handler.Delete)
+	e.POST("/:id/instantiate", handler.Instantiate)
+}
+
+func (t *TemplateHandler) Fetch(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	templates, err := t.Service.Fetch(ctx, userId)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    templates,
+	})
+}
+
+func (t *TemplateHandler) GetByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	tpl, err := t.Service.GetByID(ctx, userId, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    tpl,
+	})
+}
+
+func (t *TemplateHandler) Store(c echo.Context) (err error) {
+	var tpl domain.Template
+	err = c.Bind(&tpl)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&tpl); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = t.Service.Store(ctx, userId, &tpl)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status":  http.StatusCreated,
+		"message": "success",
+		"data":    tpl,
+	})
+}
+
+func (t *TemplateHandler) Update(c echo.Context) (err error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var tpl domain.Template
+	err = c.Bind(&tpl)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&tpl); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	tpl.ID = id
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = t.Service.Update(ctx, userId, &tpl)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    tpl,
+	})
+}
+
+func (t *TemplateHandler) Delete(c echo.Context) (err error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = t.Service.Delete(ctx, userId, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "template successfully deleted",
+	})
+}
+
+// Instantiate creates the todos of a template, counting their due dates from
+// the start query parameter, into the list given as list_id if any.
+func (t *TemplateHandler) Instantiate(c echo.Context) (err error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var listID *int64
+	if id, err := strconv.ParseInt(c.QueryParam("list_id"), 10, 64); err == nil {
+		listID = &id
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	todos, err := t.Service.Instantiate(ctx, userId, id, c.QueryParam("start"), listID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status":  http.StatusCreated,
+		"message": "success",
+		"data":    todos,
+	})
+}
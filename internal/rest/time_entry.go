@@ -0,0 +1,255 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type TimeEntryService interface {
+	GetByTodoID(ctx context.Context, userID int64, todoID int64) ([]domain.TimeEntry, error)
+	Store(ctx context.Context, userID int64, todoID int64, te *domain.TimeEntry) error
+	Delete(ctx context.Context, userID int64, todoID int64, id int64) error
+	Running(ctx context.Context, userID int64) (domain.TimeEntry, error)
+	Start(ctx context.Context, userID int64, todoID int64) (domain.TimeEntry, error)
+	Stop(ctx context.Context, userID int64, todoID int64) (domain.TimeEntry, error)
+	Report(ctx context.Context, userID int64, from string, to string) (domain.TimeReport, error)
+}
+
+type TimeEntryHandler struct {
+	Service TimeEntryService
+}
+
+// NewTimeEntryHandler serves the time entries and the timer of a todo.
+func NewTimeEntryHandler(e *echo.Group, svc TimeEntryService) {
+	handler := &TimeEntryHandler{
+		Service: svc,
+	}
+
+	e.GET("/:id/time-entries", handler.GetByTodoID)
+	e.POST("/:id/time-entries", handler.Store)
+	e.DELETE("/:id/time-entries/:entryId", handler.Delete)
+	e.POST("/:id/timer/start", handler.Start)
+	e.POST("/:id/timer/stop", handler.Stop)
+}
+
+// NewTimerHandler serves the authenticated user's running timer and time
+// report.
+func NewTimerHandler(e *echo.Group, svc TimeEntryService) {
+	handler := &TimeEntryHandler{
+		Service: svc,
+	}
+
+	e.GET("/timer", handler.Running)
+	e.GET("/time-report", handler.Report)
+}
+
+func (t *TimeEntryHandler) GetByTodoID(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	entries, err := t.Service.GetByTodoID(ctx, userId, todoID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    entries,
+	})
+}
+
+func (t *TimeEntryHandler) Store(c echo.Context) (err error) {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var te domain.TimeEntry
+	err = c.Bind(&te)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&te); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = t.Service.Store(ctx, userId, todoID, &te)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status":  http.StatusCreated,
+		"message": "success",
+		"data":    te,
+	})
+}
+
+func (t *TimeEntryHandler) Delete(c echo.Context) (err error) {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	entryID, err := strconv.ParseInt(c.Param("entryId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = t.Service.Delete(ctx, userId, todoID, entryID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "time entry successfully deleted",
+	})
+}
+
+func (t *TimeEntryHandler) Start(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	te, err := t.Service.Start(ctx, userId, todoID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status":  http.StatusCreated,
+		"message": "timer started",
+		"data":    te,
+	})
+}
+
+func (t *TimeEntryHandler) Stop(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	te, err := t.Service.Stop(ctx, userId, todoID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "timer stopped",
+		"data":    te,
+	})
+}
+
+func (t *TimeEntryHandler) Running(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	te, err := t.Service.Running(ctx, userId)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    te,
+	})
+}
+
+func (t *TimeEntryHandler) Report(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	report, err := t.Service.Report(ctx, userId, c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    report,
+	})
+}
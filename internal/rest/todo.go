@@ -2,41 +2,78 @@ package rest
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/abrahammegantoro/to-do-list-be/internal/export"
+	"github.com/abrahammegantoro/to-do-list-be/internal/markdown"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 )
 
 type TodoService interface {
 	Fetch(ctx context.Context, page int64, limit int64) ([]domain.Todo, error)
-	GetByID(ctx context.Context, id int64) (domain.Todo, error)
-	GetByUserID(ctx context.Context, userID int64, page int64, limit int64, category *string, priorityLevel *string, keyword *string) ([]domain.Todo, error)
+	GetByID(ctx context.Context, userID int64, id int64) (domain.Todo, error)
+	GetByUserID(ctx context.Context, userID int64, page int64, limit int64, filter domain.TodoFilter) ([]domain.Todo, error)
 	GetAllCategories(ctx context.Context) ([]string, error)
 	Store(ctx context.Context, td *domain.Todo) error
-	Delete(ctx context.Context, id int64) error
-	Update(ctx context.Context, td *domain.Todo) error
+	GetTrash(ctx context.Context, userID int64, page int64, limit int64) ([]domain.Todo, error)
+	Delete(ctx context.Context, userID int64, id int64, permanent bool) error
+	Restore(ctx context.Context, userID int64, id int64) (domain.Todo, error)
+	Update(ctx context.Context, userID int64, td *domain.Todo) error
+	Batch(ctx context.Context, userID int64, req domain.BatchRequest) ([]domain.BatchResult, error)
+	Move(ctx context.Context, userID int64, id int64, req domain.MoveRequest) (domain.Todo, error)
+	Archive(ctx context.Context, userID int64, id int64) (domain.Todo, error)
+	Unarchive(ctx context.Context, userID int64, id int64) (domain.Todo, error)
+	ArchiveCompleted(ctx context.Context, userID int64) (int64, error)
+	Assign(ctx context.Context, userID int64, id int64, assigneeID *int64) (domain.Todo, error)
+	AddDependency(ctx context.Context, userID int64, id int64, blockedByID int64) (domain.Todo, error)
+	RemoveDependency(ctx context.Context, userID int64, id int64, blockedByID int64) (domain.Todo, error)
+	History(ctx context.Context, userID int64, id int64, cursor string, num int64) ([]domain.TodoEvent, string, error)
+	Export(ctx context.Context, userID int64, filter domain.TodoFilter, fn func(domain.Todo) error) error
+	ParseQuick(ctx context.Context, userID int64, req domain.QuickAddRequest) (domain.Todo, error)
+}
+
+// TodoViewService resolves the view a todo list request refers to.
+type TodoViewService interface {
+	Filter(ctx context.Context, userID int64, view string) (domain.TodoFilter, error)
 }
 
 type TodoHandler struct {
 	Service TodoService
+	Views   TodoViewService
 }
 
 const defaultLimit = 10
 
-func NewTodoHandler(e *echo.Group, svc TodoService) {
+func NewTodoHandler(e *echo.Group, svc TodoService, views TodoViewService) {
 	handler := &TodoHandler{
 		Service: svc,
+		Views:   views,
 	}
 
 	e.GET("", handler.GetByUserID)
 	e.GET("/:id", handler.GetByID)
 	e.GET("/categories", handler.GetAllCategories)
+	e.GET("/trash", handler.GetTrash)
+	e.GET("/export", handler.Export)
+	e.GET("/:id/history", handler.History)
 	e.POST("", handler.Store)
+	e.POST("/batch", handler.Batch)
+	e.POST("/quick", handler.QuickAdd)
+	e.POST("/:id/restore", handler.Restore)
+	e.POST("/:id/move", handler.Move)
+	e.POST("/:id/archive", handler.Archive)
+	e.POST("/:id/unarchive", handler.Unarchive)
+	e.POST("/archive-completed", handler.ArchiveCompleted)
 	e.DELETE("/:id", handler.Delete)
 	e.PUT("/:id", handler.Update)
+	e.PUT("/:id/assignee", handler.Assign)
+	e.DELETE("/:id/assignee", handler.Unassign)
+	e.POST("/:id/dependencies", handler.AddDependency)
+	e.DELETE("/:id/dependencies/:blockerId", handler.RemoveDependency)
 }
 
 func (t *TodoHandler) FetchTodo(c echo.Context) error {
@@ -72,42 +109,133 @@ func (t *TodoHandler) FetchTodo(c echo.Context) error {
 }
 
 func (t *TodoHandler) GetByUserID(c echo.Context) error {
-    userId := c.Get("userId").(int64)
-
-    limitString := c.QueryParam("limit")
-    limit, err := strconv.Atoi(limitString)
-    if err != nil || limit == 0 {
-        limit = defaultLimit
-    }
-
-    pageString := c.QueryParam("page")
-    page, err := strconv.Atoi(pageString)
-    if err != nil || page == 0 {
-        page = 1
-    }
-
-    category := c.QueryParam("category")
-    priorityLevel := c.QueryParam("priority_level")
-    keyword := c.QueryParam("keyword")
-
-    ctx := c.Request().Context()
-
-    listTd, err := t.Service.GetByUserID(ctx, userId, int64(page), int64(limit), &category, &priorityLevel, &keyword)
-    if err != nil {
-        logrus.Error(err)
-        return c.JSON(getStatusCode(err), map[string]interface{}{
-            "status":  getStatusCode(err),
-            "message": err.Error(),
-        })
-    }
-
-    return c.JSON(http.StatusOK, map[string]interface{}{
-        "status":  http.StatusOK,
-        "message": "success",
-        "data":    listTd,
-    })
+	userId := c.Get("userId").(int64)
+
+	limitString := c.QueryParam("limit")
+	limit, err := strconv.Atoi(limitString)
+	if err != nil || limit == 0 {
+		limit = defaultLimit
+	}
+
+	pageString := c.QueryParam("page")
+	page, err := strconv.Atoi(pageString)
+	if err != nil || page == 0 {
+		page = 1
+	}
+
+	filter, err := t.todoFilter(c)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&filter); !ok {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+	}
+
+	ctx := c.Request().Context()
+
+	listTd, err := t.Service.GetByUserID(ctx, userId, int64(page), int64(limit), filter)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	for i := range listTd {
+		if err = renderNotes(c, &listTd[i]); err != nil {
+			logrus.Error(err)
+			return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+				"status":  http.StatusInternalServerError,
+				"message": err.Error(),
+			})
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    listTd,
+	})
 }
 
+// Export streams every todo matching the list filters as a file in the
+// requested format. Once the first byte is sent errors can only be logged.
+func (t *TodoHandler) Export(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+
+	format := export.Format(c.QueryParam("format"))
+	if format == "" {
+		format = export.JSON
+	}
+
+	filter, err := t.todoFilter(c)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+	if ok, err := isRequestValid(&filter); !ok {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+	}
+
+	res := c.Response()
+	enc, err := export.NewEncoder(format, res)
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	// The headers go out with the first todo so that an error before it can
+	// still be answered with a status code.
+	start := func() {
+		if res.Committed {
+			return
+		}
+		res.Header().Set(echo.HeaderContentType, format.ContentType())
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="todos.%s"`, format))
+		res.WriteHeader(http.StatusOK)
+	}
+
+	ctx := c.Request().Context()
+	err = t.Service.Export(ctx, userId, filter, func(td domain.Todo) error {
+		start()
+		return enc.Encode(td)
+	})
+	if err != nil {
+		logrus.Error(err)
+		if res.Committed {
+			return nil
+		}
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	start()
+	if err = enc.Close(); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
 
 func (t *TodoHandler) GetByID(c echo.Context) error {
 	idP, err := strconv.Atoi(c.Param("id"))
@@ -119,9 +247,10 @@ func (t *TodoHandler) GetByID(c echo.Context) error {
 	}
 
 	id := int64(idP)
+	userId := c.Get("userId").(int64)
 	ctx := c.Request().Context()
 
-	td, err := t.Service.GetByID(ctx, id)
+	td, err := t.Service.GetByID(ctx, userId, id)
 	if err != nil {
 		logrus.Error(err)
 		return c.JSON(getStatusCode(err), map[string]interface{}{
@@ -130,6 +259,14 @@ func (t *TodoHandler) GetByID(c echo.Context) error {
 		})
 	}
 
+	if err = renderNotes(c, &td); err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"status":  http.StatusInternalServerError,
+			"message": err.Error(),
+		})
+	}
+
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status":  http.StatusOK,
 		"message": "success",
@@ -160,7 +297,7 @@ func (t *TodoHandler) Store(c echo.Context) (err error) {
 
 	var todo domain.Todo
 	todo.UserID = userId
-	
+
 	err = c.Bind(&todo)
 	if err != nil {
 		logrus.Error(err)
@@ -196,6 +333,71 @@ func (t *TodoHandler) Store(c echo.Context) (err error) {
 	})
 }
 
+// QuickAdd reads a todo from a line of text. It answers with the parsed todo
+// as a preview, and stores it when the request is confirmed.
+func (t *TodoHandler) QuickAdd(c echo.Context) (err error) {
+	userId := c.Get("userId").(int64)
+
+	var req domain.QuickAddRequest
+	err = c.Bind(&req)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+	}
+
+	ctx := c.Request().Context()
+	todo, err := t.Service.ParseQuick(ctx, userId, req)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	if !req.Confirm {
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"status":  http.StatusOK,
+			"message": "preview",
+			"data":    todo,
+		})
+	}
+
+	if ok, err = isRequestValid(&todo); !ok {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+			"data":    todo,
+		})
+	}
+
+	err = t.Service.Store(ctx, &todo)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status":  http.StatusCreated,
+		"message": "success",
+		"data":    todo,
+	})
+}
+
 func (t *TodoHandler) Delete(c echo.Context) (err error) {
 	idP, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -207,9 +409,11 @@ func (t *TodoHandler) Delete(c echo.Context) (err error) {
 	}
 
 	id := int64(idP)
+	userId := c.Get("userId").(int64)
+	permanent, _ := strconv.ParseBool(c.QueryParam("permanent"))
 	ctx := c.Request().Context()
 
-	err = t.Service.Delete(ctx, id)
+	err = t.Service.Delete(ctx, userId, id, permanent)
 	if err != nil {
 		logrus.Error(err)
 		return c.JSON(getStatusCode(err), map[string]interface{}{
@@ -224,7 +428,40 @@ func (t *TodoHandler) Delete(c echo.Context) (err error) {
 	})
 }
 
-func (t *TodoHandler) Update(c echo.Context) (err error) {
+func (t *TodoHandler) GetTrash(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+
+	limitString := c.QueryParam("limit")
+	limit, err := strconv.Atoi(limitString)
+	if err != nil || limit == 0 {
+		limit = defaultLimit
+	}
+
+	pageString := c.QueryParam("page")
+	page, err := strconv.Atoi(pageString)
+	if err != nil || page == 0 {
+		page = 1
+	}
+
+	ctx := c.Request().Context()
+
+	listTd, err := t.Service.GetTrash(ctx, userId, int64(page), int64(limit))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    listTd,
+	})
+}
+
+func (t *TodoHandler) Restore(c echo.Context) (err error) {
 	idP, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		logrus.Error(err)
@@ -236,22 +473,49 @@ func (t *TodoHandler) Update(c echo.Context) (err error) {
 
 	id := int64(idP)
 	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
 
-	var todo domain.Todo
-	todo.UserID = userId
-	err = c.Bind(&todo)
+	td, err := t.Service.Restore(ctx, userId, id)
 	if err != nil {
-		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
 	}
 
-	var ok bool
-	if ok, err = isRequestValid(&todo); !ok {
-		return c.JSON(http.StatusBadRequest, err.Error())
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "item successfully restored",
+		"data":    td,
+	})
+}
+
+func (t *TodoHandler) Move(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	id := int64(idP)
+	userId := c.Get("userId").(int64)
+
+	var req domain.MoveRequest
+	err = c.Bind(&req)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
 	}
 
 	ctx := c.Request().Context()
-	todo.ID = id
-	err = t.Service.Update(ctx, &todo)
+	td, err := t.Service.Move(ctx, userId, id, req)
 	if err != nil {
 		logrus.Error(err)
 		return c.JSON(getStatusCode(err), map[string]interface{}{
@@ -263,6 +527,473 @@ func (t *TodoHandler) Update(c echo.Context) (err error) {
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status":  http.StatusOK,
 		"message": "success",
-		"data":    todo,
+		"data":    td,
+	})
+}
+
+func (t *TodoHandler) Archive(c echo.Context) error {
+	return t.setArchived(c, t.Service.Archive)
+}
+
+func (t *TodoHandler) Unarchive(c echo.Context) error {
+	return t.setArchived(c, t.Service.Unarchive)
+}
+
+func (t *TodoHandler) setArchived(c echo.Context, action func(ctx context.Context, userID int64, id int64) (domain.Todo, error)) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	id := int64(idP)
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	td, err := action(ctx, userId, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    td,
+	})
+}
+
+func (t *TodoHandler) ArchiveCompleted(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	archived, err := t.Service.ArchiveCompleted(ctx, userId)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data": map[string]interface{}{
+			"archived": archived,
+		},
+	})
+}
+
+func (t *TodoHandler) Update(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	id := int64(idP)
+	userId := c.Get("userId").(int64)
+
+	var todo domain.Todo
+	todo.UserID = userId
+	err = c.Bind(&todo)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&todo); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	todo.ID = id
+	err = t.Service.Update(ctx, userId, &todo)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    todo,
+	})
+}
+
+func (t *TodoHandler) Batch(c echo.Context) (err error) {
+	userId := c.Get("userId").(int64)
+
+	var req domain.BatchRequest
+	err = c.Bind(&req)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	for i := range req.Operations {
+		if req.Operations[i].Todo != nil {
+			req.Operations[i].Todo.UserID = userId
+		}
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&req); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+	}
+
+	for i, op := range req.Operations {
+		if err = validateBatchOperation(op); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"status":  http.StatusBadRequest,
+				"message": fmt.Sprintf("operations[%d]: %s", i, err.Error()),
+			})
+		}
+	}
+
+	ctx := c.Request().Context()
+	results, err := t.Service.Batch(ctx, userId, req)
+	if err == domain.ErrBatchAborted {
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+			"data":    results,
+		})
+	}
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    results,
+	})
+}
+
+func validateBatchOperation(op domain.BatchOperation) error {
+	switch op.Op {
+	case domain.BatchCreate:
+		if op.Todo == nil {
+			return fmt.Errorf("todo is required for %s", op.Op)
+		}
+	case domain.BatchUpdate:
+		if op.ID == 0 || op.Todo == nil {
+			return fmt.Errorf("id and todo are required for %s", op.Op)
+		}
+	case domain.BatchDelete, domain.BatchComplete:
+		if op.ID == 0 {
+			return fmt.Errorf("id is required for %s", op.Op)
+		}
+	}
+
+	return nil
+}
+
+// renderNotes fills in the sanitized HTML form of the notes when the client
+// asked for it with format=html.
+func renderNotes(c echo.Context, td *domain.Todo) (err error) {
+	if c.QueryParam("format") != "html" {
+		return nil
+	}
+
+	td.NotesHTML, err = markdown.Render(td.Notes)
+	return
+}
+
+// todoFilter reads the list filters of the request. Given a view, its filter
+// is the starting point and the other parameters override it.
+func (t *TodoHandler) todoFilter(c echo.Context) (res domain.TodoFilter, err error) {
+	filter := queryFilter(c)
+	view := c.QueryParam("view")
+	if view == "" {
+		return filter, nil
+	}
+
+	userId := c.Get("userId").(int64)
+	res, err = t.Views.Filter(c.Request().Context(), userId, view)
+	if err != nil {
+		return domain.TodoFilter{}, err
+	}
+
+	if filter.ListID != nil {
+		res.ListID = filter.ListID
+	}
+	if filter.Category != "" {
+		res.Category = filter.Category
+	}
+	if filter.PriorityLevel != "" {
+		res.PriorityLevel = filter.PriorityLevel
+	}
+	if filter.Keyword != "" {
+		res.Keyword = filter.Keyword
+	}
+	if c.QueryParam("archived") != "" {
+		res.Archived = filter.Archived
+	}
+	if filter.Assigned != "" {
+		res.Assigned = filter.Assigned
+	}
+	if filter.Due != "" {
+		res.Due = filter.Due
+	}
+	if filter.Blocked != nil {
+		res.Blocked = filter.Blocked
+	}
+	if filter.Sort != "" {
+		res.Sort = filter.Sort
+	}
+
+	return
+}
+
+// queryFilter reads the filters of the todo list from the query string.
+// Malformed numbers and booleans count as not given.
+func queryFilter(c echo.Context) domain.TodoFilter {
+	filter := domain.TodoFilter{
+		Category:      c.QueryParam("category"),
+		PriorityLevel: domain.PriorityLevel(c.QueryParam("priority_level")),
+		Keyword:       c.QueryParam("keyword"),
+		Sort:          domain.TodoSort(c.QueryParam("sort")),
+		Assigned:      c.QueryParam("assigned"),
+		Due:           c.QueryParam("due"),
+	}
+	if level, ok := domain.ParsePriority(c.QueryParam("priority_level")); ok {
+		filter.PriorityLevel = level
+	}
+	filter.Archived, _ = strconv.ParseBool(c.QueryParam("archived"))
+	if listID, err := strconv.ParseInt(c.QueryParam("list_id"), 10, 64); err == nil {
+		filter.ListID = &listID
+	}
+	if blocked, err := strconv.ParseBool(c.QueryParam("blocked")); err == nil {
+		filter.Blocked = &blocked
+	}
+
+	return filter
+}
+
+func (t *TodoHandler) Assign(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	id := int64(idP)
+	userId := c.Get("userId").(int64)
+
+	var req domain.AssignRequest
+	err = c.Bind(&req)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&req); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	ctx := c.Request().Context()
+	td, err := t.Service.Assign(ctx, userId, id, &req.AssigneeID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    td,
+	})
+}
+
+func (t *TodoHandler) Unassign(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	id := int64(idP)
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	td, err := t.Service.Assign(ctx, userId, id, nil)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    td,
+	})
+}
+
+func (t *TodoHandler) AddDependency(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	id := int64(idP)
+	userId := c.Get("userId").(int64)
+
+	var req domain.DependencyRequest
+	err = c.Bind(&req)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&req); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	ctx := c.Request().Context()
+	td, err := t.Service.AddDependency(ctx, userId, id, req.BlockedByID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    td,
+	})
+}
+
+func (t *TodoHandler) RemoveDependency(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	blockedByID, err := strconv.ParseInt(c.Param("blockerId"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	id := int64(idP)
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	td, err := t.Service.RemoveDependency(ctx, userId, id, blockedByID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    td,
+	})
+}
+
+func (t *TodoHandler) History(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit == 0 {
+		limit = defaultLimit
+	}
+
+	id := int64(idP)
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	events, nextCursor, err := t.Service.History(ctx, userId, id, c.QueryParam("cursor"), int64(limit))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":      http.StatusOK,
+		"message":     "success",
+		"data":        events,
+		"next_cursor": nextCursor,
 	})
 }
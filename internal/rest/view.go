@@ -0,0 +1,209 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type ViewService interface {
+	Fetch(ctx context.Context, userID int64) ([]domain.SavedView, error)
+	SmartViews(ctx context.Context) []domain.SmartView
+	GetByID(ctx context.Context, userID int64, id int64) (domain.SavedView, error)
+	Store(ctx context.Context, userID int64, sv *domain.SavedView) error
+	Update(ctx context.Context, userID int64, sv *domain.SavedView) error
+	Delete(ctx context.Context, userID int64, id int64) error
+}
+
+type ViewHandler struct {
+	Service ViewService
+}
+
+func NewViewHandler(e *echo.Group, svc ViewService) {
+	handler := &ViewHandler{
+		Service: svc,
+	}
+
+	e.GET("", handler.Fetch)
+	e.GET("/smart", handler.SmartViews)
+	e.POST("", handler.Store)
+	e.GET("/:id", handler.GetByID)
+	e.PUT("/:id", handler.Update)
+	e.DELETE("/:id", handler.Delete)
+}
+
+func (v *ViewHandler) Fetch(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	views, err := v.Service.Fetch(ctx, userId)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    views,
+	})
+}
+
+func (v *ViewHandler) SmartViews(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    v.Service.SmartViews(ctx),
+	})
+}
+
+func (v *ViewHandler) GetByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	sv, err := v.Service.GetByID(ctx, userId, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    sv,
+	})
+}
+
+func (v *ViewHandler) Store(c echo.Context) (err error) {
+	var sv domain.SavedView
+	err = c.Bind(&sv)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&sv); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = v.Service.Store(ctx, userId, &sv)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status":  http.StatusCreated,
+		"message": "success",
+		"data":    sv,
+	})
+}
+
+func (v *ViewHandler) Update(c echo.Context) (err error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var sv domain.SavedView
+	err = c.Bind(&sv)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&sv); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	sv.ID = id
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = v.Service.Update(ctx, userId, &sv)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    sv,
+	})
+}
+
+func (v *ViewHandler) Delete(c echo.Context) (err error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = v.Service.Delete(ctx, userId, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "view successfully deleted",
+	})
+}
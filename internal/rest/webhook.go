@@ -0,0 +1,271 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type WebhookService interface {
+	Fetch(ctx context.Context, userID int64) ([]domain.Webhook, error)
+	GetByID(ctx context.Context, userID int64, id int64) (domain.Webhook, error)
+	Store(ctx context.Context, userID int64, wh *domain.Webhook) error
+	Update(ctx context.Context, userID int64, wh *domain.Webhook) error
+	Delete(ctx context.Context, userID int64, id int64) error
+	GetDeliveries(ctx context.Context, userID int64, id int64, cursor string, num int64) ([]domain.WebhookDelivery, string, error)
+	Replay(ctx context.Context, userID int64, id int64, deliveryID int64) (int64, error)
+}
+
+type WebhookHandler struct {
+	Service WebhookService
+}
+
+func NewWebhookHandler(e *echo.Group, svc WebhookService) {
+	handler := &WebhookHandler{
+		Service: svc,
+	}
+
+	e.GET("", handler.Fetch)
+	e.POST("", handler.Store)
+	e.GET("/:id", handler.GetByID)
+	e.PUT("/:id", handler.Update)
+	e.DELETE("/:id", handler.Delete)
+	e.GET("/:id/deliveries", handler.GetDeliveries)
+	e.POST("/:id/deliveries/:deliveryId/replay", handler.Replay)
+}
+
+func (w *WebhookHandler) Fetch(c echo.Context) error {
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	webhooks, err := w.Service.Fetch(ctx, userId)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    webhooks,
+	})
+}
+
+func (w *WebhookHandler) GetByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	wh, err := w.Service.GetByID(ctx, userId, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    wh,
+	})
+}
+
+func (w *WebhookHandler) Store(c echo.Context) (err error) {
+	var wh domain.Webhook
+	err = c.Bind(&wh)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&wh); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = w.Service.Store(ctx, userId, &wh)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status":  http.StatusCreated,
+		"message": "success",
+		"data":    wh,
+	})
+}
+
+func (w *WebhookHandler) Update(c echo.Context) (err error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var wh domain.Webhook
+	err = c.Bind(&wh)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&wh); !ok {
+		logrus.Error(err)
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	wh.ID = id
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = w.Service.Update(ctx, userId, &wh)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "success",
+		"data":    wh,
+	})
+}
+
+func (w *WebhookHandler) Delete(c echo.Context) (err error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	err = w.Service.Delete(ctx, userId, id)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  http.StatusOK,
+		"message": "webhook successfully deleted",
+	})
+}
+
+func (w *WebhookHandler) GetDeliveries(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit == 0 {
+		limit = defaultLimit
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	deliveries, nextCursor, err := w.Service.GetDeliveries(ctx, userId, id, c.QueryParam("cursor"), int64(limit))
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":      http.StatusOK,
+		"message":     "success",
+		"data":        deliveries,
+		"next_cursor": nextCursor,
+	})
+}
+
+func (w *WebhookHandler) Replay(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	deliveryID, err := strconv.ParseInt(c.Param("deliveryId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	userId := c.Get("userId").(int64)
+	ctx := c.Request().Context()
+
+	messageID, err := w.Service.Replay(ctx, userId, id, deliveryID)
+	if err != nil {
+		logrus.Error(err)
+		return c.JSON(getStatusCode(err), map[string]interface{}{
+			"status":  getStatusCode(err),
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusAccepted, map[string]interface{}{
+		"status":  http.StatusAccepted,
+		"message": "delivery queued",
+		"data":    map[string]interface{}{"message_id": messageID},
+	})
+}
@@ -0,0 +1,202 @@
+package list
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type ListRepository interface {
+	GetByUserID(ctx context.Context, userID int64) ([]domain.List, error)
+	GetByID(ctx context.Context, id int64, userID int64) (domain.List, error)
+	Store(ctx context.Context, list *domain.List) error
+	Update(ctx context.Context, list *domain.List) error
+	Delete(ctx context.Context, id int64) error
+	GetMembers(ctx context.Context, listID int64) ([]domain.ListMember, error)
+	GetMemberRole(ctx context.Context, listID int64, userID int64) (domain.ListRole, error)
+	LockOwners(ctx context.Context, listID int64) (int64, error)
+	AddMember(ctx context.Context, member *domain.ListMember) error
+	UpdateMemberRole(ctx context.Context, listID int64, userID int64, role domain.ListRole) error
+	RemoveMember(ctx context.Context, listID int64, userID int64) error
+}
+
+type UserRepository interface {
+	GetByUsername(ctx context.Context, username string) (domain.User, error)
+}
+
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type ListService struct {
+	listRepository ListRepository
+	userRepository UserRepository
+	transactor     Transactor
+}
+
+func NewListService(lr ListRepository, ur UserRepository, tx Transactor) *ListService {
+	return &ListService{
+		listRepository: lr,
+		userRepository: ur,
+		transactor:     tx,
+	}
+}
+
+// requireRole returns the list as seen by userID, or ErrNotFound when the
+// user is not a member and ErrForbidden when their role is too low.
+func (l *ListService) requireRole(ctx context.Context, userID int64, listID int64, required domain.ListRole) (res domain.List, err error) {
+	res, err = l.listRepository.GetByID(ctx, listID, userID)
+	if err != nil {
+		return
+	}
+	if !res.Role.Allows(required) {
+		return domain.List{}, domain.ErrForbidden
+	}
+
+	return
+}
+
+func (l *ListService) Fetch(ctx context.Context, userID int64) (res []domain.List, err error) {
+	return l.listRepository.GetByUserID(ctx, userID)
+}
+
+func (l *ListService) GetByID(ctx context.Context, userID int64, id int64) (res domain.List, err error) {
+	return l.requireRole(ctx, userID, id, domain.RoleViewer)
+}
+
+// Store creates the list and makes its creator the first owner.
+func (l *ListService) Store(ctx context.Context, userID int64, list *domain.List) (err error) {
+	list.CreatedAt = time.Now()
+	list.UpdatedAt = time.Now()
+	list.Role = domain.RoleOwner
+
+	return l.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := l.listRepository.Store(ctx, list)
+		if err != nil {
+			return err
+		}
+
+		return l.listRepository.AddMember(ctx, &domain.ListMember{
+			ListID:    list.ID,
+			UserID:    userID,
+			Role:      domain.RoleOwner,
+			CreatedAt: list.CreatedAt,
+		})
+	})
+}
+
+func (l *ListService) Update(ctx context.Context, userID int64, list *domain.List) (err error) {
+	existedList, err := l.requireRole(ctx, userID, list.ID, domain.RoleOwner)
+	if err != nil {
+		return
+	}
+
+	list.Role = existedList.Role
+	list.CreatedAt = existedList.CreatedAt
+	list.UpdatedAt = time.Now()
+	return l.listRepository.Update(ctx, list)
+}
+
+func (l *ListService) Delete(ctx context.Context, userID int64, id int64) (err error) {
+	_, err = l.requireRole(ctx, userID, id, domain.RoleOwner)
+	if err != nil {
+		return
+	}
+
+	return l.listRepository.Delete(ctx, id)
+}
+
+func (l *ListService) GetMembers(ctx context.Context, userID int64, listID int64) (res []domain.ListMember, err error) {
+	_, err = l.requireRole(ctx, userID, listID, domain.RoleViewer)
+	if err != nil {
+		return
+	}
+
+	return l.listRepository.GetMembers(ctx, listID)
+}
+
+// AddMember lets an owner invite another user to the list by username.
+func (l *ListService) AddMember(ctx context.Context, userID int64, listID int64, invitation *domain.ListInvitation) (res domain.ListMember, err error) {
+	_, err = l.requireRole(ctx, userID, listID, domain.RoleOwner)
+	if err != nil {
+		return
+	}
+
+	user, err := l.userRepository.GetByUsername(ctx, invitation.Username)
+	if err != nil {
+		return domain.ListMember{}, domain.ErrNotFound
+	}
+
+	res = domain.ListMember{
+		ListID:    listID,
+		UserID:    user.ID,
+		Username:  user.Username,
+		Name:      user.Name,
+		Role:      invitation.Role,
+		CreatedAt: time.Now(),
+	}
+	err = l.listRepository.AddMember(ctx, &res)
+	if err != nil {
+		return domain.ListMember{}, err
+	}
+
+	return
+}
+
+func (l *ListService) UpdateMemberRole(ctx context.Context, userID int64, listID int64, memberID int64, role domain.ListRole) (err error) {
+	_, err = l.requireRole(ctx, userID, listID, domain.RoleOwner)
+	if err != nil {
+		return
+	}
+
+	return l.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if role != domain.RoleOwner {
+			if err := l.checkNotLastOwner(ctx, listID, memberID); err != nil {
+				return err
+			}
+		}
+
+		return l.listRepository.UpdateMemberRole(ctx, listID, memberID, role)
+	})
+}
+
+// RemoveMember removes memberID from the list. Owners may remove anyone;
+// every member may remove themselves to leave the list.
+func (l *ListService) RemoveMember(ctx context.Context, userID int64, listID int64, memberID int64) (err error) {
+	required := domain.RoleOwner
+	if memberID == userID {
+		required = domain.RoleViewer
+	}
+
+	_, err = l.requireRole(ctx, userID, listID, required)
+	if err != nil {
+		return
+	}
+
+	return l.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := l.checkNotLastOwner(ctx, listID, memberID); err != nil {
+			return err
+		}
+
+		return l.listRepository.RemoveMember(ctx, listID, memberID)
+	})
+}
+
+func (l *ListService) checkNotLastOwner(ctx context.Context, listID int64, memberID int64) (err error) {
+	owners, err := l.listRepository.LockOwners(ctx, listID)
+	if err != nil {
+		return
+	}
+
+	role, err := l.listRepository.GetMemberRole(ctx, listID, memberID)
+	if err != nil {
+		return
+	}
+
+	if role == domain.RoleOwner && owners <= 1 {
+		return domain.ErrLastOwner
+	}
+
+	return
+}
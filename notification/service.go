@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type NotificationRepository interface {
+	GetByUserID(ctx context.Context, userID int64, unread bool, cursor string, num int64) ([]domain.Notification, string, error)
+	CountUnread(ctx context.Context, userID int64) (int64, error)
+	Store(ctx context.Context, nt *domain.Notification) error
+	MarkRead(ctx context.Context, userID int64, id int64, readAt time.Time) error
+	MarkAllRead(ctx context.Context, userID int64, readAt time.Time) (int64, error)
+}
+
+// NotificationService is the in-app inbox. It is also a notifier, so
+// reminders, assignments and mentions are delivered by storing them here.
+type NotificationService struct {
+	notificationRepository NotificationRepository
+}
+
+func NewNotificationService(nr NotificationRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepository: nr,
+	}
+}
+
+// Notify puts the notification in the user's inbox. Called with a
+// transaction in ctx it becomes part of that transaction.
+func (n *NotificationService) Notify(ctx context.Context, nt domain.Notification) error {
+	if nt.CreatedAt.IsZero() {
+		nt.CreatedAt = time.Now()
+	}
+
+	return n.notificationRepository.Store(ctx, &nt)
+}
+
+func (n *NotificationService) Fetch(ctx context.Context, userID int64, unread bool, cursor string, num int64) (res []domain.Notification, nextCursor string, err error) {
+	return n.notificationRepository.GetByUserID(ctx, userID, unread, cursor, num)
+}
+
+func (n *NotificationService) UnreadCount(ctx context.Context, userID int64) (res int64, err error) {
+	return n.notificationRepository.CountUnread(ctx, userID)
+}
+
+func (n *NotificationService) MarkRead(ctx context.Context, userID int64, id int64) (err error) {
+	return n.notificationRepository.MarkRead(ctx, userID, id, time.Now())
+}
+
+// MarkAllRead marks every unread notification of the user read and returns
+// how many there were.
+func (n *NotificationService) MarkAllRead(ctx context.Context, userID int64) (res int64, err error) {
+	return n.notificationRepository.MarkAllRead(ctx, userID, time.Now())
+}
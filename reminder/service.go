@@ -0,0 +1,227 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type ReminderRepository interface {
+	GetByTodoID(ctx context.Context, todoID int64) ([]domain.Reminder, error)
+	Replace(ctx context.Context, todoID int64, offsets []int, createdAt time.Time) error
+	LockDue(ctx context.Context, now time.Time, limit int64) ([]domain.DueReminder, error)
+	MarkSent(ctx context.Context, id int64, sentFor time.Time, sentAt time.Time) error
+	Enqueue(ctx context.Context, reminderID int64, channels []string, n domain.Notification) error
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int64) ([]domain.ReminderMessage, error)
+	MarkDelivered(ctx context.Context, id int64, attempts int, deliveredAt time.Time) error
+	ScheduleRetry(ctx context.Context, id int64, attempts int, lastError string, nextAttemptAt time.Time) error
+	MarkFailed(ctx context.Context, id int64, attempts int, lastError string, failedAt time.Time) error
+}
+
+type TodoAuthorizer interface {
+	Authorize(ctx context.Context, userID int64, id int64, required domain.ListRole) (domain.Todo, error)
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, n domain.Notification) error
+}
+
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+const (
+	// dispatchBatchSize is how many reminders one scheduler pass queues.
+	dispatchBatchSize = 100
+	// deliverBatchSize is how many messages one delivery pass claims. They
+	// are sent one after another within deliverLease.
+	deliverBatchSize = 20
+	deliverLease     = 10 * time.Minute
+	// maxAttempts is how often a message is tried before it is given up on.
+	maxAttempts = 8
+	retryBase   = 30 * time.Second
+	retryMax    = time.Hour
+	// dueDateLayout shows the date of an all-day todo.
+	dueDateLayout = "Mon, 02 Jan 2006"
+)
+
+type ReminderService struct {
+	reminderRepository ReminderRepository
+	todoAuthorizer     TodoAuthorizer
+	inbox              Notifier
+	channels           map[string]Notifier
+	channelNames       []string
+	transactor         Transactor
+}
+
+// NewReminderService returns a ReminderService that puts reminders in the
+// inbox and sends them over the given channels, keyed by name.
+func NewReminderService(rr ReminderRepository, ta TodoAuthorizer, inbox Notifier, channels map[string]Notifier, tx Transactor) *ReminderService {
+	names := make([]string, 0, len(channels))
+	for name := range channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &ReminderService{
+		reminderRepository: rr,
+		todoAuthorizer:     ta,
+		inbox:              inbox,
+		channels:           channels,
+		channelNames:       names,
+		transactor:         tx,
+	}
+}
+
+func (r *ReminderService) GetByTodoID(ctx context.Context, userID int64, todoID int64) (res []domain.Reminder, err error) {
+	if _, err = r.todoAuthorizer.Authorize(ctx, userID, todoID, domain.RoleViewer); err != nil {
+		return
+	}
+
+	return r.reminderRepository.GetByTodoID(ctx, todoID)
+}
+
+// Set replaces the reminders of the todo with the given offsets.
+func (r *ReminderService) Set(ctx context.Context, userID int64, todoID int64, req *domain.ReminderRequest) (res []domain.Reminder, err error) {
+	if _, err = r.todoAuthorizer.Authorize(ctx, userID, todoID, domain.RoleEditor); err != nil {
+		return
+	}
+
+	offsets := req.OffsetMinutes
+	if offsets == nil {
+		offsets = []int{}
+	}
+
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return r.reminderRepository.Replace(ctx, todoID, offsets, time.Now())
+	})
+	if err != nil {
+		return
+	}
+
+	return r.reminderRepository.GetByTodoID(ctx, todoID)
+}
+
+// Dispatch marks the reminders that are due as sent, puts them in the inbox,
+// queues them once for every channel and returns how many there were. The
+// inbox is written in the same transaction, so it gets each reminder once. A reminder goes to the
+// todo's assignee, or to its creator when nobody is assigned. Rows are locked
+// with SKIP LOCKED, so every replica can run the scheduler. Deliver sends
+// the queued messages.
+func (r *ReminderService) Dispatch(ctx context.Context) (res int64, err error) {
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		due, err := r.reminderRepository.LockDue(ctx, time.Now(), dispatchBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range due {
+			if err = r.reminderRepository.MarkSent(ctx, d.Reminder.ID, *d.Todo.Date, time.Now()); err != nil {
+				return err
+			}
+
+			n := reminderNotification(d)
+			if err = r.inbox.Notify(ctx, n); err != nil {
+				return err
+			}
+			if len(r.channelNames) > 0 {
+				if err = r.reminderRepository.Enqueue(ctx, d.Reminder.ID, r.channelNames, n); err != nil {
+					return err
+				}
+			}
+			res++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return
+}
+
+// Deliver sends the queued reminder messages that are due and returns how
+// many were delivered. Each message is one reminder over one channel, so a
+// failing channel is retried with exponential backoff on its own and given
+// up on after maxAttempts. Messages are claimed with a lease and sent outside
+// of any transaction.
+func (r *ReminderService) Deliver(ctx context.Context) (res int64, err error) {
+	now := time.Now()
+
+	messages, err := r.reminderRepository.ClaimDue(ctx, now, now.Add(deliverLease), deliverBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, msg := range messages {
+		attempts := msg.Attempts + 1
+
+		var sendErr error
+		if channel, ok := r.channels[msg.Channel]; ok {
+			sendErr = channel.Notify(ctx, msg.Notification)
+		} else {
+			sendErr = fmt.Errorf("unknown channel %q", msg.Channel)
+		}
+
+		now := time.Now()
+		switch {
+		case sendErr == nil:
+			err = r.reminderRepository.MarkDelivered(ctx, msg.ID, attempts, now)
+			res++
+		case attempts >= maxAttempts:
+			logrus.WithFields(logrus.Fields{"message_id": msg.ID, "channel": msg.Channel}).Error(sendErr)
+			err = r.reminderRepository.MarkFailed(ctx, msg.ID, attempts, sendErr.Error(), now)
+		default:
+			err = r.reminderRepository.ScheduleRetry(ctx, msg.ID, attempts, sendErr.Error(), now.Add(backoff(attempts)))
+		}
+		if err != nil {
+			return res, err
+		}
+	}
+
+	return
+}
+
+// backoff returns how long to wait before the next attempt after the given
+// number of failed attempts: 30s, 1m, 2m, ... up to 1h.
+func backoff(attempts int) time.Duration {
+	delay := retryBase << (attempts - 1)
+	if delay > retryMax || delay <= 0 {
+		return retryMax
+	}
+
+	return delay
+}
+
+// reminderNotification tells the recipient when the todo is due, in their
+// own zone. An all-day todo is due on a date rather than at a time.
+func reminderNotification(d domain.DueReminder) domain.Notification {
+	recipient := d.Todo.UserID
+	if d.Todo.AssigneeID != nil {
+		recipient = *d.Todo.AssigneeID
+	}
+
+	due := d.Todo.Date.UTC().Format(dueDateLayout)
+	if !d.Todo.AllDay {
+		loc, err := time.LoadLocation(d.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		due = d.Todo.Date.In(loc).Format(time.RFC1123)
+	}
+
+	todoID := d.Todo.ID
+	return domain.Notification{
+		UserID:    recipient,
+		Kind:      domain.NotificationReminder,
+		Title:     "Reminder: " + d.Todo.Text,
+		Body:      fmt.Sprintf("%q is due %s.", d.Todo.Text, due),
+		TodoID:    &todoID,
+		CreatedAt: time.Now(),
+	}
+}
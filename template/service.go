@@ -0,0 +1,175 @@
+package template
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type TemplateRepository interface {
+	GetByUserID(ctx context.Context, userID int64) ([]domain.Template, error)
+	GetByID(ctx context.Context, id int64) (domain.Template, error)
+	Store(ctx context.Context, tpl *domain.Template) error
+	Update(ctx context.Context, tpl *domain.Template) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type TodoCreator interface {
+	StoreAll(ctx context.Context, userID int64, todos []domain.Todo, prepare func(ctx context.Context) (*int64, error)) error
+}
+
+type ListCreator interface {
+	Store(ctx context.Context, userID int64, list *domain.List) error
+}
+
+type UserRepository interface {
+	GetSettings(ctx context.Context, id int64) (domain.UserSettings, error)
+}
+
+type TemplateService struct {
+	templateRepository TemplateRepository
+	todoCreator        TodoCreator
+	listCreator        ListCreator
+	userRepository     UserRepository
+}
+
+func NewTemplateService(tr TemplateRepository, tc TodoCreator, lc ListCreator, ur UserRepository) *TemplateService {
+	return &TemplateService{
+		templateRepository: tr,
+		todoCreator:        tc,
+		listCreator:        lc,
+		userRepository:     ur,
+	}
+}
+
+func (t *TemplateService) Fetch(ctx context.Context, userID int64) (res []domain.Template, err error) {
+	return t.templateRepository.GetByUserID(ctx, userID)
+}
+
+func (t *TemplateService) GetByID(ctx context.Context, userID int64, id int64) (res domain.Template, err error) {
+	res, err = t.templateRepository.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if res.UserID != userID {
+		return domain.Template{}, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (t *TemplateService) Store(ctx context.Context, userID int64, tpl *domain.Template) (err error) {
+	tpl.UserID = userID
+	tpl.CreatedAt = time.Now()
+	tpl.UpdatedAt = tpl.CreatedAt
+
+	return t.templateRepository.Store(ctx, tpl)
+}
+
+func (t *TemplateService) Update(ctx context.Context, userID int64, tpl *domain.Template) (err error) {
+	existedTemplate, err := t.GetByID(ctx, userID, tpl.ID)
+	if err != nil {
+		return
+	}
+
+	tpl.UserID = existedTemplate.UserID
+	tpl.CreatedAt = existedTemplate.CreatedAt
+	tpl.UpdatedAt = time.Now()
+
+	return t.templateRepository.Update(ctx, tpl)
+}
+
+func (t *TemplateService) Delete(ctx context.Context, userID int64, id int64) (err error) {
+	if _, err = t.GetByID(ctx, userID, id); err != nil {
+		return
+	}
+
+	return t.templateRepository.Delete(ctx, id)
+}
+
+// Instantiate creates the todos of the template, all or none, with their due
+// dates counted from start, a date in the user's time zone that defaults to
+// today. They are personal todos unless listID is given; a project template
+// creates a new list for them instead.
+func (t *TemplateService) Instantiate(ctx context.Context, userID int64, id int64, start string, listID *int64) (res []domain.Todo, err error) {
+	tpl, err := t.GetByID(ctx, userID, id)
+	if err != nil {
+		return
+	}
+	if tpl.Project && listID != nil {
+		return nil, domain.ErrBadParamInput
+	}
+
+	settings, err := t.userRepository.GetSettings(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	loc, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		return
+	}
+
+	now := time.Now().In(loc)
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if start != "" {
+		if day, err = time.ParseInLocation("2006-01-02", start, loc); err != nil {
+			return nil, domain.ErrBadParamInput
+		}
+	}
+
+	res = make([]domain.Todo, 0, len(tpl.Items))
+	for _, item := range tpl.Items {
+		td, err := itemTodo(item, day, loc)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, td)
+	}
+
+	err = t.todoCreator.StoreAll(ctx, userID, res, func(ctx context.Context) (*int64, error) {
+		if !tpl.Project {
+			return listID, nil
+		}
+
+		list := domain.List{Name: tpl.Name}
+		if err := t.listCreator.Store(ctx, userID, &list); err != nil {
+			return nil, err
+		}
+
+		return &list.ID, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// itemTodo returns the todo of item when the template starts on day.
+func itemTodo(item domain.TemplateItem, day time.Time, loc *time.Location) (td domain.Todo, err error) {
+	td = domain.Todo{
+		Text:            item.Text,
+		Notes:           item.Notes,
+		Category:        item.Category,
+		PriorityLevel:   item.PriorityLevel,
+		EstimateMinutes: item.EstimateMinutes,
+	}
+
+	due := day.AddDate(0, 0, item.DueOffsetDays)
+	if item.DueTime == "" {
+		td.Date = &due
+		td.AllDay = true
+		return
+	}
+
+	clock, err := time.Parse("15:04", item.DueTime)
+	if err != nil {
+		return domain.Todo{}, domain.ErrBadParamInput
+	}
+	due = time.Date(due.Year(), due.Month(), due.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+	td.Date = &due
+
+	return
+}
@@ -0,0 +1,185 @@
+package timeentry
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type TimeEntryRepository interface {
+	GetByTodoID(ctx context.Context, todoID int64) ([]domain.TimeEntry, error)
+	GetByID(ctx context.Context, id int64) (domain.TimeEntry, error)
+	GetRunning(ctx context.Context, userID int64) (domain.TimeEntry, error)
+	Store(ctx context.Context, te *domain.TimeEntry) error
+	StopRunning(ctx context.Context, userID int64, endedAt time.Time) error
+	Delete(ctx context.Context, id int64) error
+	GetReport(ctx context.Context, userID int64, timezone string, from time.Time, to time.Time) (domain.TimeReport, error)
+}
+
+type TodoAuthorizer interface {
+	Authorize(ctx context.Context, userID int64, id int64, required domain.ListRole) (domain.Todo, error)
+}
+
+type UserRepository interface {
+	GetSettings(ctx context.Context, id int64) (domain.UserSettings, error)
+}
+
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+const (
+	// reportDays is the number of days up to today a report covers when no
+	// range is given.
+	reportDays = 7
+	// maxReportDays caps the range of a report.
+	maxReportDays = 366
+)
+
+// TimeEntryService tracks the time users spend on todos, with a timer or by
+// entering it afterwards. Tracking time on a todo takes the editor role.
+type TimeEntryService struct {
+	timeEntryRepository TimeEntryRepository
+	todoAuthorizer      TodoAuthorizer
+	userRepository      UserRepository
+	transactor          Transactor
+}
+
+func NewTimeEntryService(ter TimeEntryRepository, ta TodoAuthorizer, ur UserRepository, tx Transactor) *TimeEntryService {
+	return &TimeEntryService{
+		timeEntryRepository: ter,
+		todoAuthorizer:      ta,
+		userRepository:      ur,
+		transactor:          tx,
+	}
+}
+
+func (t *TimeEntryService) GetByTodoID(ctx context.Context, userID int64, todoID int64) (res []domain.TimeEntry, err error) {
+	if _, err = t.todoAuthorizer.Authorize(ctx, userID, todoID, domain.RoleViewer); err != nil {
+		return
+	}
+
+	return t.timeEntryRepository.GetByTodoID(ctx, todoID)
+}
+
+// Store adds a finished entry entered by hand.
+func (t *TimeEntryService) Store(ctx context.Context, userID int64, todoID int64, te *domain.TimeEntry) (err error) {
+	if _, err = t.todoAuthorizer.Authorize(ctx, userID, todoID, domain.RoleEditor); err != nil {
+		return
+	}
+
+	te.TodoID = todoID
+	te.UserID = userID
+	te.CreatedAt = time.Now()
+
+	return t.timeEntryRepository.Store(ctx, te)
+}
+
+// Delete removes one of the user's own entries of the todo.
+func (t *TimeEntryService) Delete(ctx context.Context, userID int64, todoID int64, id int64) (err error) {
+	if _, err = t.todoAuthorizer.Authorize(ctx, userID, todoID, domain.RoleEditor); err != nil {
+		return
+	}
+
+	te, err := t.timeEntryRepository.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if te.TodoID != todoID || te.UserID != userID {
+		return domain.ErrNotFound
+	}
+
+	return t.timeEntryRepository.Delete(ctx, id)
+}
+
+// Running returns the user's running timer, or ErrNotFound.
+func (t *TimeEntryService) Running(ctx context.Context, userID int64) (res domain.TimeEntry, err error) {
+	return t.timeEntryRepository.GetRunning(ctx, userID)
+}
+
+// Start starts a timer on the todo. A timer the user has running on any todo
+// is stopped first, so only one runs at a time.
+func (t *TimeEntryService) Start(ctx context.Context, userID int64, todoID int64) (res domain.TimeEntry, err error) {
+	if _, err = t.todoAuthorizer.Authorize(ctx, userID, todoID, domain.RoleEditor); err != nil {
+		return
+	}
+
+	now := time.Now()
+	res = domain.TimeEntry{
+		TodoID:    todoID,
+		UserID:    userID,
+		StartedAt: now,
+		CreatedAt: now,
+	}
+
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := t.timeEntryRepository.StopRunning(ctx, userID, now); err != nil {
+			return err
+		}
+
+		return t.timeEntryRepository.Store(ctx, &res)
+	})
+	if err != nil {
+		return domain.TimeEntry{}, err
+	}
+
+	return
+}
+
+// Stop stops the user's timer on the todo, or fails with ErrNotFound when it
+// is not running.
+func (t *TimeEntryService) Stop(ctx context.Context, userID int64, todoID int64) (res domain.TimeEntry, err error) {
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		running, err := t.timeEntryRepository.GetRunning(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if running.TodoID != todoID {
+			return domain.ErrNotFound
+		}
+
+		if err = t.timeEntryRepository.StopRunning(ctx, userID, time.Now()); err != nil {
+			return err
+		}
+
+		res, err = t.timeEntryRepository.GetByID(ctx, running.ID)
+		return err
+	})
+	if err != nil {
+		return domain.TimeEntry{}, err
+	}
+
+	return
+}
+
+// Report sums the time the user tracked by category. from and to are dates
+// such as 2024-10-01 in the user's time zone; the range defaults to the last
+// reportDays days.
+func (t *TimeEntryService) Report(ctx context.Context, userID int64, from string, to string) (res domain.TimeReport, err error) {
+	settings, err := t.userRepository.GetSettings(ctx, userID)
+	if err != nil {
+		return domain.TimeReport{}, err
+	}
+
+	loc, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		return domain.TimeReport{}, err
+	}
+
+	start, end, err := domain.ParseDateRange(from, to, loc, reportDays, maxReportDays)
+	if err != nil {
+		return domain.TimeReport{}, err
+	}
+
+	res, err = t.timeEntryRepository.GetReport(ctx, userID, settings.Timezone, start, end)
+	if err != nil {
+		return domain.TimeReport{}, err
+	}
+
+	res.Timezone = settings.Timezone
+	res.From = start.Format("2006-01-02")
+	res.To = end.Format("2006-01-02")
+
+	return
+}
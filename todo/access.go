@@ -0,0 +1,87 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+// authorize checks that userID may act on td with at least the required
+// role. A personal todo is only visible to its creator, who owns it. For a
+// todo on a shared list the user's membership role on that list decides.
+// Users without any access get ErrNotFound so that ids do not leak.
+func (t *TodoService) authorize(ctx context.Context, userID int64, td domain.Todo, required domain.ListRole) error {
+	if td.ListID == nil {
+		if td.UserID != userID {
+			return domain.ErrNotFound
+		}
+
+		return nil
+	}
+
+	return t.authorizeList(ctx, userID, *td.ListID, required)
+}
+
+func (t *TodoService) authorizeList(ctx context.Context, userID int64, listID int64, required domain.ListRole) error {
+	role, err := t.listRepository.GetMemberRole(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+	if !role.Allows(required) {
+		return domain.ErrForbidden
+	}
+
+	return nil
+}
+
+// Authorize loads the todo and checks that userID has at least the required
+// role on it. Other services use it to guard resources hanging off a todo.
+func (t *TodoService) Authorize(ctx context.Context, userID int64, id int64, required domain.ListRole) (res domain.Todo, err error) {
+	res, err = t.todoRepository.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = t.authorize(ctx, userID, res, required)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	return
+}
+
+// checkAssignee verifies that the assignee of td, if any, can see the todo.
+// A personal todo can only be assigned to its creator, a list todo to any
+// member of the list.
+func (t *TodoService) checkAssignee(ctx context.Context, td domain.Todo) error {
+	if td.AssigneeID == nil {
+		return nil
+	}
+
+	err := t.authorize(ctx, *td.AssigneeID, td, domain.RoleViewer)
+	if err == domain.ErrNotFound || err == domain.ErrForbidden {
+		return domain.ErrAssigneeAccess
+	}
+
+	return err
+}
+
+// lock loads the todo for update and checks that userID has at least the
+// required role on it. Call it within a transaction.
+func (t *TodoService) lock(ctx context.Context, userID int64, id int64, required domain.ListRole) (res domain.Todo, err error) {
+	locked, err := t.todoRepository.GetByIDsForUpdate(ctx, []int64{id})
+	if err != nil {
+		return
+	}
+	if len(locked) == 0 {
+		return res, domain.ErrNotFound
+	}
+
+	res = locked[0]
+	err = t.authorize(ctx, userID, res, required)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	return
+}
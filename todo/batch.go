@@ -0,0 +1,247 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+// Batch applies every operation of req inside a single transaction. Each
+// operation runs in its own savepoint so one failure does not undo the
+// others, unless req.Atomic is set, in which case the first failure rolls
+// the whole batch back and ErrBatchAborted is returned with the results.
+func (t *TodoService) Batch(ctx context.Context, userID int64, req domain.BatchRequest) (res []domain.BatchResult, err error) {
+	var ids []int64
+	for _, op := range req.Operations {
+		if op.Op != domain.BatchCreate {
+			ids = append(ids, op.ID)
+		}
+	}
+
+	var locked, unblocked []domain.Todo
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		res = make([]domain.BatchResult, 0, len(req.Operations))
+
+		locked, err = t.todoRepository.GetByIDsForUpdate(ctx, ids)
+		if err != nil {
+			return err
+		}
+		if err = t.withDetails(ctx, userID, locked); err != nil {
+			return err
+		}
+
+		accessible := make(map[int64]domain.Todo, len(locked))
+		for _, td := range locked {
+			if t.authorize(ctx, userID, td, domain.RoleEditor) == nil {
+				accessible[td.ID] = td
+			}
+		}
+
+		for i, op := range req.Operations {
+			result := domain.BatchResult{Index: i, Op: op.Op, ID: op.ID}
+
+			opErr := t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				return t.applyBatchOperation(ctx, userID, op, accessible, &result, &unblocked)
+			})
+			if opErr == nil {
+				result.Status = domain.BatchStatusOK
+				res = append(res, result)
+				continue
+			}
+
+			result.Status = domain.BatchStatusFailed
+			result.Error = opErr.Error()
+			res = append(res, result)
+
+			if req.Atomic {
+				for j := range res[:i] {
+					res[j].Status = domain.BatchStatusRolledBack
+					res[j].Data = nil
+				}
+				for j, skipped := range req.Operations[i+1:] {
+					res = append(res, domain.BatchResult{Index: i + 1 + j, Op: skipped.Op, ID: skipped.ID, Status: domain.BatchStatusSkipped})
+				}
+
+				return domain.ErrBatchAborted
+			}
+		}
+
+		return nil
+	})
+	if err == domain.ErrBatchAborted {
+		return res, err
+	}
+	if err == nil {
+		t.publishBatch(ctx, userID, res, locked, unblocked)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (t *TodoService) applyBatchOperation(ctx context.Context, userID int64, op domain.BatchOperation, accessible map[int64]domain.Todo, result *domain.BatchResult, unblocked *[]domain.Todo) (err error) {
+	now := time.Now()
+
+	if op.Op == domain.BatchCreate {
+		td := *op.Todo
+		td.UserID = userID
+		if td.ListID != nil {
+			if err = t.authorizeList(ctx, userID, *td.ListID, domain.RoleEditor); err != nil {
+				return
+			}
+		}
+		if err = t.checkAssignee(ctx, td); err != nil {
+			return
+		}
+		normalizeDate(&td)
+		td.TrackedSeconds = 0
+		td.BlockedBy = []int64{}
+		td.Blocking = []int64{}
+		td.CreatedAt = now
+		td.UpdatedAt = now
+
+		err = t.todoRepository.Store(ctx, &td)
+		if err != nil {
+			return
+		}
+		if err = t.record(ctx, userID, td.ID, domain.TodoCreated, nil); err != nil {
+			return
+		}
+		if err = t.enqueueWebhook(ctx, domain.WebhookTodoCreated, td); err != nil {
+			return
+		}
+
+		result.ID = td.ID
+		result.Data = &td
+		return
+	}
+
+	existedTodo, ok := accessible[op.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+
+	switch op.Op {
+	case domain.BatchUpdate:
+		td := *op.Todo
+		if !td.ListIDSet {
+			td.ListID = existedTodo.ListID
+		}
+		if td.ListID != nil && (existedTodo.ListID == nil || *td.ListID != *existedTodo.ListID) {
+			if err = t.authorizeList(ctx, userID, *td.ListID, domain.RoleEditor); err != nil {
+				return
+			}
+		}
+		td.ID = op.ID
+		td.UserID = existedTodo.UserID
+		td.AssigneeID = existedTodo.AssigneeID
+		td.ArchivedAt = existedTodo.ArchivedAt
+		td.TrackedSeconds = existedTodo.TrackedSeconds
+		td.BlockedBy = existedTodo.BlockedBy
+		td.Blocking = existedTodo.Blocking
+		td.CreatedAt = existedTodo.CreatedAt
+		td.UpdatedAt = now
+		normalizeDate(&td)
+		if err = t.keepAssignee(ctx, &td); err != nil {
+			return
+		}
+
+		err = t.todoRepository.Update(ctx, &td)
+		if err != nil {
+			return
+		}
+		if err = t.recordUpdate(ctx, userID, existedTodo, td); err != nil {
+			return
+		}
+		if err = t.batchCompleted(ctx, userID, existedTodo, td, unblocked); err != nil {
+			return
+		}
+
+		accessible[td.ID] = td
+		result.Data = &td
+	case domain.BatchComplete:
+		td := existedTodo
+		td.Completed = true
+		td.UpdatedAt = now
+
+		err = t.todoRepository.Update(ctx, &td)
+		if err != nil {
+			return
+		}
+		if err = t.recordUpdate(ctx, userID, existedTodo, td); err != nil {
+			return
+		}
+		if err = t.batchCompleted(ctx, userID, existedTodo, td, unblocked); err != nil {
+			return
+		}
+
+		accessible[td.ID] = td
+		result.Data = &td
+	case domain.BatchDelete:
+		err = t.todoRepository.Delete(ctx, op.ID, now)
+		if err != nil {
+			return
+		}
+		if err = t.record(ctx, userID, op.ID, domain.TodoDeleted, nil); err != nil {
+			return
+		}
+
+		var res []domain.Todo
+		if res, err = t.unblockDeleted(ctx, userID, existedTodo); err != nil {
+			return
+		}
+		*unblocked = append(*unblocked, res...)
+
+		delete(accessible, op.ID)
+	default:
+		return domain.ErrBadParamInput
+	}
+
+	return
+}
+
+// batchCompleted queues the events of a batch operation that completes td and
+// collects the todos it unblocked. They are only appended once the events are
+// queued so a failed savepoint leaves no trace of them.
+func (t *TodoService) batchCompleted(ctx context.Context, userID int64, old domain.Todo, td domain.Todo, unblocked *[]domain.Todo) error {
+	res, err := t.enqueueCompleted(ctx, userID, old, td)
+	if err != nil {
+		return err
+	}
+
+	*unblocked = append(*unblocked, res...)
+	return nil
+}
+
+// publishBatch announces the operations that were applied once the batch
+// has been committed. locked holds the todos as they were before the batch
+// and unblocked the todos its completions and deletions unblocked.
+func (t *TodoService) publishBatch(ctx context.Context, userID int64, results []domain.BatchResult, locked []domain.Todo, unblocked []domain.Todo) {
+	before := make(map[int64]domain.Todo, len(locked))
+	for _, td := range locked {
+		before[td.ID] = td
+	}
+
+	for _, result := range results {
+		if result.Status != domain.BatchStatusOK {
+			continue
+		}
+
+		switch result.Op {
+		case domain.BatchCreate:
+			t.publish(ctx, userID, domain.TodoCreated, *result.Data, nil)
+		case domain.BatchUpdate, domain.BatchComplete:
+			previous := before[result.ID]
+			t.publish(ctx, userID, domain.TodoUpdated, *result.Data, &previous)
+		case domain.BatchDelete:
+			t.publish(ctx, userID, domain.TodoDeleted, before[result.ID], nil)
+		}
+	}
+
+	for _, td := range unblocked {
+		t.publish(ctx, userID, domain.TodoUnblocked, td, nil)
+	}
+}
@@ -0,0 +1,117 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+// AddDependency makes the todo blocked by another one until that one is
+// completed. It takes editor access to the todo and sight of the blocker.
+func (t *TodoService) AddDependency(ctx context.Context, userID int64, id int64, blockedByID int64) (res domain.Todo, err error) {
+	if id == blockedByID {
+		return domain.Todo{}, domain.ErrDependencyCycle
+	}
+
+	return t.changeDependency(ctx, userID, id, func(ctx context.Context) error {
+		if _, err := t.Authorize(ctx, userID, blockedByID, domain.RoleViewer); err != nil {
+			return err
+		}
+
+		return t.todoRepository.AddDependency(ctx, id, blockedByID, time.Now())
+	})
+}
+
+func (t *TodoService) RemoveDependency(ctx context.Context, userID int64, id int64, blockedByID int64) (res domain.Todo, err error) {
+	return t.changeDependency(ctx, userID, id, func(ctx context.Context) error {
+		return t.todoRepository.RemoveDependency(ctx, id, blockedByID)
+	})
+}
+
+// changeDependency runs change on the dependencies of the todo and records
+// the change of its blocked_by list in the history.
+func (t *TodoService) changeDependency(ctx context.Context, userID int64, id int64, change func(ctx context.Context) error) (res domain.Todo, err error) {
+	var existedTodo domain.Todo
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		existedTodo, err = t.lock(ctx, userID, id, domain.RoleEditor)
+		if err != nil {
+			return err
+		}
+		if err = t.withDetail(ctx, userID, &existedTodo); err != nil {
+			return err
+		}
+
+		if err = change(ctx); err != nil {
+			return err
+		}
+
+		res, err = t.todoRepository.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err = t.withDetail(ctx, userID, &res); err != nil {
+			return err
+		}
+		if equalIDs(existedTodo.BlockedBy, res.BlockedBy) {
+			return nil
+		}
+
+		return t.record(ctx, userID, id, domain.TodoUpdated, map[string]domain.FieldChange{
+			"blocked_by": {Old: existedTodo.BlockedBy, New: res.BlockedBy},
+		})
+	})
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	t.publish(ctx, userID, domain.TodoUpdated, res, &existedTodo)
+	return
+}
+
+// unblock records and queues the unblocked event for every todo that waited
+// only on td, which was just completed or moved to the trash, and returns
+// those todos. Call it in the transaction that completes or deletes td.
+func (t *TodoService) unblock(ctx context.Context, actorID int64, td domain.Todo) (res []domain.Todo, err error) {
+	res, err = t.todoRepository.GetUnblocked(ctx, td.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err = t.withDetails(ctx, actorID, res); err != nil {
+		return nil, err
+	}
+
+	for _, unblocked := range res {
+		if err = t.record(ctx, actorID, unblocked.ID, domain.TodoUnblocked, nil); err != nil {
+			return nil, err
+		}
+		if err = t.enqueueWebhook(ctx, domain.WebhookTodoUnblocked, unblocked); err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
+
+// unblockDeleted is unblock for a todo that was just moved to the trash. A
+// completed todo was no longer holding anything up.
+func (t *TodoService) unblockDeleted(ctx context.Context, actorID int64, td domain.Todo) ([]domain.Todo, error) {
+	if td.Completed {
+		return nil, nil
+	}
+
+	return t.unblock(ctx, actorID, td)
+}
+
+func equalIDs(a []int64, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
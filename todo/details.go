@@ -0,0 +1,65 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+// exportChunkSize is how many exported todos have their details loaded at
+// once.
+const exportChunkSize = 500
+
+// withDetails fills in the tracked time and the dependencies of todos that
+// are about to be returned to userID. Plain loads leave them out, as they
+// mostly serve to lock and check todos. Only dependencies userID can see are
+// listed.
+func (t *TodoService) withDetails(ctx context.Context, userID int64, todos []domain.Todo) error {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(todos))
+	for i, td := range todos {
+		ids[i] = td.ID
+	}
+
+	tracked, err := t.todoRepository.GetTrackedSeconds(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	blockedBy, blocking, err := t.todoRepository.GetDependencies(ctx, userID, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range todos {
+		id := todos[i].ID
+		todos[i].TrackedSeconds = tracked[id]
+		todos[i].BlockedBy = orEmpty(blockedBy[id])
+		todos[i].Blocking = orEmpty(blocking[id])
+	}
+
+	return nil
+}
+
+// orEmpty keeps a todo without dependencies from listing them as null.
+func orEmpty(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+
+	return ids
+}
+
+// withDetail is withDetails for a single todo.
+func (t *TodoService) withDetail(ctx context.Context, userID int64, td *domain.Todo) error {
+	todos := []domain.Todo{*td}
+	if err := t.withDetails(ctx, userID, todos); err != nil {
+		return err
+	}
+
+	*td = todos[0]
+	return nil
+}
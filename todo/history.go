@@ -0,0 +1,125 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+// record adds an event to the history of the todo. Call it in the same
+// transaction as the change it describes.
+func (t *TodoService) record(ctx context.Context, actorID int64, todoID int64, eventType domain.TodoEventType, changes map[string]domain.FieldChange) error {
+	if changes == nil {
+		changes = map[string]domain.FieldChange{}
+	}
+
+	return t.eventRepository.Store(ctx, &domain.TodoEvent{
+		TodoID:    todoID,
+		ActorID:   actorID,
+		Type:      eventType,
+		Changes:   changes,
+		CreatedAt: time.Now(),
+	})
+}
+
+// recordUpdate records the fields that differ between old and new, if any.
+func (t *TodoService) recordUpdate(ctx context.Context, actorID int64, old domain.Todo, new domain.Todo) error {
+	changes := diffTodo(old, new)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return t.record(ctx, actorID, new.ID, domain.TodoUpdated, changes)
+}
+
+// diffTodo compares the user-editable fields of two versions of a todo.
+// Derived fields such as position and completed_at are left out.
+func diffTodo(old domain.Todo, new domain.Todo) map[string]domain.FieldChange {
+	changes := map[string]domain.FieldChange{}
+
+	if old.Text != new.Text {
+		changes["text"] = domain.FieldChange{Old: old.Text, New: new.Text}
+	}
+	if old.Notes != new.Notes {
+		changes["notes"] = domain.FieldChange{Old: old.Notes, New: new.Notes}
+	}
+	if old.Category != new.Category {
+		changes["category"] = domain.FieldChange{Old: old.Category, New: new.Category}
+	}
+	if !equalTime(old.Date, new.Date) {
+		changes["date"] = domain.FieldChange{Old: old.Date, New: new.Date}
+	}
+	if old.AllDay != new.AllDay {
+		changes["all_day"] = domain.FieldChange{Old: old.AllDay, New: new.AllDay}
+	}
+	if old.PriorityLevel != new.PriorityLevel {
+		changes["priority_level"] = domain.FieldChange{Old: old.PriorityLevel, New: new.PriorityLevel}
+	}
+	if old.Completed != new.Completed {
+		changes["completed"] = domain.FieldChange{Old: old.Completed, New: new.Completed}
+	}
+	if !equalRule(old.RecurrenceRule, new.RecurrenceRule) {
+		changes["recurrence_rule"] = domain.FieldChange{Old: old.RecurrenceRule, New: new.RecurrenceRule}
+	}
+	if !equalMinutes(old.EstimateMinutes, new.EstimateMinutes) {
+		changes["estimate_minutes"] = domain.FieldChange{Old: old.EstimateMinutes, New: new.EstimateMinutes}
+	}
+	if !equalID(old.ListID, new.ListID) {
+		changes["list_id"] = domain.FieldChange{Old: old.ListID, New: new.ListID}
+	}
+	if !equalID(old.AssigneeID, new.AssigneeID) {
+		changes["assignee_id"] = domain.FieldChange{Old: old.AssigneeID, New: new.AssigneeID}
+	}
+	if !equalTime(old.ArchivedAt, new.ArchivedAt) {
+		changes["archived_at"] = domain.FieldChange{Old: old.ArchivedAt, New: new.ArchivedAt}
+	}
+
+	return changes
+}
+
+func equalRule(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func equalMinutes(a *int, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func equalID(a *int64, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func equalTime(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+// History returns the change history of a todo, newest first.
+func (t *TodoService) History(ctx context.Context, userID int64, id int64, cursor string, num int64) (res []domain.TodoEvent, nextCursor string, err error) {
+	if _, err = t.Authorize(ctx, userID, id, domain.RoleViewer); err != nil {
+		return
+	}
+
+	return t.eventRepository.GetByTodoID(ctx, id, cursor, num)
+}
+
+// Activity returns the events of every todo the user can see, newest first.
+func (t *TodoService) Activity(ctx context.Context, userID int64, cursor string, num int64) (res []domain.TodoEvent, nextCursor string, err error) {
+	return t.eventRepository.GetFeed(ctx, userID, cursor, num)
+}
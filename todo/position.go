@@ -0,0 +1,144 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+// minPositionGap is the smallest distance kept between two neighbours before
+// their category is rebalanced.
+const minPositionGap = 1e-6
+
+// Move changes the manual position of a todo relative to its anchors. Only
+// the moved row is rewritten, unless the gap between the anchors has become
+// too small, in which case the category is rebalanced first.
+func (t *TodoService) Move(ctx context.Context, userID int64, id int64, req domain.MoveRequest) (res domain.Todo, err error) {
+	if req.Before == nil && req.After == nil {
+		return domain.Todo{}, domain.ErrBadParamInput
+	}
+
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		ids := []int64{id}
+		if req.Before != nil {
+			ids = append(ids, *req.Before)
+		}
+		if req.After != nil {
+			ids = append(ids, *req.After)
+		}
+
+		locked, err := t.todoRepository.GetByIDsForUpdate(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		todos := make(map[int64]domain.Todo, len(locked))
+		for _, td := range locked {
+			todos[td.ID] = td
+		}
+
+		td, ok := todos[id]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		if err = t.authorize(ctx, userID, td, domain.RoleEditor); err != nil {
+			return err
+		}
+		if err = t.withDetail(ctx, userID, &td); err != nil {
+			return err
+		}
+
+		// Positions are kept per creator and category, so anchors must
+		// belong to the same group as the moved todo.
+		for _, anchorID := range ids[1:] {
+			anchor, ok := todos[anchorID]
+			if !ok {
+				return domain.ErrNotFound
+			}
+			if anchor.ID == td.ID || anchor.UserID != td.UserID || anchor.Category != td.Category {
+				return domain.ErrBadParamInput
+			}
+		}
+
+		position, err := t.positionBetween(ctx, td, req, todos)
+		if err == errPositionGapExhausted {
+			err = t.todoRepository.RebalancePositions(ctx, td.UserID, td.Category)
+			if err != nil {
+				return err
+			}
+
+			locked, err = t.todoRepository.GetByIDsForUpdate(ctx, ids)
+			if err != nil {
+				return err
+			}
+			for _, r := range locked {
+				todos[r.ID] = r
+			}
+
+			position, err = t.positionBetween(ctx, td, req, todos)
+		}
+		if err != nil {
+			return err
+		}
+
+		td.Position = position
+		td.UpdatedAt = time.Now()
+		err = t.todoRepository.UpdatePosition(ctx, td.ID, td.Position, td.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		res = td
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	t.publish(ctx, userID, domain.TodoUpdated, res, nil)
+	return
+}
+
+var errPositionGapExhausted = errors.New("no room left between anchors")
+
+func (t *TodoService) positionBetween(ctx context.Context, td domain.Todo, req domain.MoveRequest, todos map[int64]domain.Todo) (res float64, err error) {
+	var lower, upper float64
+	var hasLower, hasUpper bool
+
+	if req.After != nil {
+		lower, hasLower = todos[*req.After].Position, true
+	}
+	if req.Before != nil {
+		upper, hasUpper = todos[*req.Before].Position, true
+	}
+
+	switch {
+	case hasLower && hasUpper:
+		if lower >= upper {
+			return 0, domain.ErrBadParamInput
+		}
+	case hasLower:
+		upper, hasUpper, err = t.todoRepository.NeighbourPosition(ctx, td.UserID, td.Category, lower, false, td.ID)
+	case hasUpper:
+		lower, hasLower, err = t.todoRepository.NeighbourPosition(ctx, td.UserID, td.Category, upper, true, td.ID)
+	}
+	if err != nil {
+		return
+	}
+
+	// Without a neighbour on the far side the repository returned the
+	// position one step past the anchor, which the todo takes as is.
+	switch {
+	case hasLower && hasUpper:
+		if upper-lower < minPositionGap {
+			return 0, errPositionGapExhausted
+		}
+		return lower + (upper-lower)/2, nil
+	case hasLower:
+		return upper, nil
+	default:
+		return lower, nil
+	}
+}
@@ -0,0 +1,140 @@
+package todo
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+// positionRepository keeps todos in memory for the position queries. Calls
+// to any other method panic on the nil embedded interface.
+type positionRepository struct {
+	TodoRepository
+	todos      map[int64]domain.Todo
+	rebalanced []int64
+}
+
+func (r *positionRepository) GetByIDsForUpdate(ctx context.Context, ids []int64) (res []domain.Todo, err error) {
+	for _, id := range ids {
+		if td, ok := r.todos[id]; ok {
+			res = append(res, td)
+		}
+	}
+
+	return
+}
+
+func (r *positionRepository) NeighbourPosition(ctx context.Context, userID int64, category string, position float64, before bool, excludeID int64) (float64, bool, error) {
+	found := false
+	var res float64
+	for _, td := range r.todos {
+		if td.UserID != userID || td.Category != category || td.ID == excludeID {
+			continue
+		}
+		if before && td.Position < position && (!found || td.Position > res) ||
+			!before && td.Position > position && (!found || td.Position < res) {
+			res, found = td.Position, true
+		}
+	}
+	if !found {
+		if before {
+			return position - 1024, false, nil
+		}
+		return position + 1024, false, nil
+	}
+
+	return res, true, nil
+}
+
+func (r *positionRepository) RebalancePositions(ctx context.Context, userID int64, category string) error {
+	r.rebalanced = append(r.rebalanced, userID)
+
+	var group []domain.Todo
+	for _, td := range r.todos {
+		if td.UserID == userID && td.Category == category {
+			group = append(group, td)
+		}
+	}
+	sort.Slice(group, func(i, j int) bool { return group[i].Position < group[j].Position })
+	for i, td := range group {
+		td.Position = float64(i+1) * 1024
+		r.todos[td.ID] = td
+	}
+
+	return nil
+}
+
+func (r *positionRepository) UpdatePosition(ctx context.Context, id int64, position float64, updatedAt time.Time) error {
+	td := r.todos[id]
+	td.Position = position
+	r.todos[id] = td
+	return nil
+}
+
+func (r *positionRepository) GetTrackedSeconds(ctx context.Context, ids []int64) (map[int64]int64, error) {
+	return nil, nil
+}
+
+func (r *positionRepository) GetDependencies(ctx context.Context, userID int64, ids []int64) (map[int64][]int64, map[int64][]int64, error) {
+	return nil, nil, nil
+}
+
+// memberRepository gives every user the same role on every list.
+type memberRepository struct {
+	role    domain.ListRole
+	members []domain.ListMember
+}
+
+func (r memberRepository) GetMembers(ctx context.Context, listID int64) ([]domain.ListMember, error) {
+	return r.members, nil
+}
+
+func (r memberRepository) GetMemberRole(ctx context.Context, listID int64, userID int64) (domain.ListRole, error) {
+	return r.role, nil
+}
+
+type discardPublisher struct{}
+
+func (discardPublisher) Publish(ctx context.Context, userIDs []int64, ev domain.StreamEvent) error {
+	return nil
+}
+
+type inlineTransactor struct{}
+
+func (inlineTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestMoveRebalancesOwnerCategory(t *testing.T) {
+	const owner, editor = 1, 2
+	listID := int64(10)
+
+	repo := &positionRepository{todos: map[int64]domain.Todo{}}
+	for id, position := range map[int64]float64{1: 1000, 2: 1000 + minPositionGap/2, 3: 3000} {
+		repo.todos[id] = domain.Todo{ID: id, UserID: owner, ListID: &listID, Category: "Work", Position: position}
+	}
+	// The editor's own todos in the same category must stay as they are.
+	repo.todos[4] = domain.Todo{ID: 4, UserID: editor, ListID: &listID, Category: "Work", Position: 5}
+
+	service := NewTodoService(repo, nil, memberRepository{role: domain.RoleEditor}, nil, nil, nil, discardPublisher{}, inlineTransactor{})
+
+	after, before := int64(1), int64(2)
+	res, err := service.Move(context.Background(), editor, 3, domain.MoveRequest{After: &after, Before: &before})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repo.rebalanced) != 1 || repo.rebalanced[0] != owner {
+		t.Errorf("rebalanced users %v, want [%d]", repo.rebalanced, owner)
+	}
+	lower, upper := repo.todos[1].Position, repo.todos[2].Position
+	if res.Position <= lower || res.Position >= upper {
+		t.Errorf("position %v, want between %v and %v", res.Position, lower, upper)
+	}
+	if repo.todos[4].Position != 5 {
+		t.Errorf("editor's todo moved to %v", repo.todos[4].Position)
+	}
+}
@@ -0,0 +1,55 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/abrahammegantoro/to-do-list-be/internal/quickadd"
+)
+
+// quickCategory files quick-added todos that name no #category.
+const quickCategory = "Inbox"
+
+// ParseQuick turns a line of free text into a todo without storing it. Dates
+// are read in the zone of the request or else the user's. A todo without a
+// time is all-day, and one with neither a date nor a time has no date.
+func (t *TodoService) ParseQuick(ctx context.Context, userID int64, req domain.QuickAddRequest) (res domain.Todo, err error) {
+	zone := req.Timezone
+	if zone == "" {
+		settings, err := t.userRepository.GetSettings(ctx, userID)
+		if err != nil {
+			return domain.Todo{}, err
+		}
+		zone = settings.Timezone
+	}
+
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return domain.Todo{}, domain.ErrBadParamInput
+	}
+
+	now := time.Now().In(loc)
+	parsed := quickadd.Parse(req.Text, now)
+
+	res = domain.Todo{
+		Text:          parsed.Text,
+		Category:      parsed.Category,
+		AllDay:        !parsed.HasTime,
+		PriorityLevel: parsed.Priority,
+		UserID:        userID,
+		ListID:        req.ListID,
+	}
+	if !parsed.Due.IsZero() {
+		res.Date = &parsed.Due
+	}
+	if res.Category == "" {
+		res.Category = quickCategory
+	}
+	if res.PriorityLevel == "" {
+		res.PriorityLevel = domain.Medium
+	}
+	normalizeDate(&res)
+
+	return
+}
@@ -2,6 +2,7 @@ package todo
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/abrahammegantoro/to-do-list-be/domain"
@@ -10,23 +11,93 @@ import (
 type TodoRepository interface {
 	Fetch(ctx context.Context, limit int64, offset int64) ([]domain.Todo, error)
 	GetByID(ctx context.Context, id int64) (domain.Todo, error)
-	GetByUserID(ctx context.Context, userID int64, limit int64, offset int64, category *string, priorityLevel *string, keyword *string) ([]domain.Todo, error)
+	GetTrashedByID(ctx context.Context, id int64) (domain.Todo, error)
+	GetByIDsForUpdate(ctx context.Context, ids []int64) ([]domain.Todo, error)
+	GetByUserID(ctx context.Context, userID int64, limit int64, offset int64, filter domain.TodoFilter) ([]domain.Todo, error)
+	StreamByUserID(ctx context.Context, userID int64, filter domain.TodoFilter, fn func(domain.Todo) error) error
+	GetTrashByUserID(ctx context.Context, userID int64, limit int64, offset int64) ([]domain.Todo, error)
 	GetAllCategories(ctx context.Context) ([]string, error)
 	Store(ctx context.Context, td *domain.Todo) error
+	StoreMany(ctx context.Context, userID int64, todos []domain.Todo) (int64, error)
 	Update(ctx context.Context, td *domain.Todo) error
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, id int64, deletedAt time.Time) error
+	Restore(ctx context.Context, id int64, updatedAt time.Time) error
+	HardDelete(ctx context.Context, id int64) error
+	PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error)
+	NeighbourPosition(ctx context.Context, userID int64, category string, position float64, before bool, excludeID int64) (float64, bool, error)
+	UpdatePosition(ctx context.Context, id int64, position float64, updatedAt time.Time) error
+	RebalancePositions(ctx context.Context, userID int64, category string) error
+	SetArchived(ctx context.Context, id int64, archivedAt *time.Time, updatedAt time.Time) error
+	ArchiveCompleted(ctx context.Context, userID int64, archivedAt time.Time) ([]int64, error)
+	AutoArchive(ctx context.Context, now time.Time) (map[int64]int64, error)
+	SetAssignee(ctx context.Context, id int64, assigneeID *int64, updatedAt time.Time) error
+	LockOverdue(ctx context.Context, now time.Time, limit int64) ([]domain.Todo, error)
+	SetOverdueNotified(ctx context.Context, ids []int64, notifiedAt time.Time) error
+	GetStats(ctx context.Context, userID int64, timezone string, from time.Time, to time.Time) (domain.Stats, error)
+	AddDependency(ctx context.Context, todoID int64, blockedByID int64, createdAt time.Time) error
+	RemoveDependency(ctx context.Context, todoID int64, blockedByID int64) error
+	GetUnblocked(ctx context.Context, blockerID int64) ([]domain.Todo, error)
+	GetTrackedSeconds(ctx context.Context, ids []int64) (map[int64]int64, error)
+	GetDependencies(ctx context.Context, userID int64, ids []int64) (map[int64][]int64, map[int64][]int64, error)
+}
+
+type TodoEventRepository interface {
+	GetByTodoID(ctx context.Context, todoID int64, cursor string, num int64) ([]domain.TodoEvent, string, error)
+	GetFeed(ctx context.Context, userID int64, cursor string, num int64) ([]domain.TodoEvent, string, error)
+	Store(ctx context.Context, ev *domain.TodoEvent) error
+}
+
+type ListRepository interface {
+	GetMembers(ctx context.Context, listID int64) ([]domain.ListMember, error)
+	GetMemberRole(ctx context.Context, listID int64, userID int64) (domain.ListRole, error)
+}
+
+type UserRepository interface {
+	GetSettings(ctx context.Context, id int64) (domain.UserSettings, error)
+}
+
+type WebhookOutbox interface {
+	Enqueue(ctx context.Context, userIDs []int64, payload domain.WebhookPayload) error
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, n domain.Notification) error
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, userIDs []int64, ev domain.StreamEvent) error
+}
+
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type TodoService struct {
-	todoRepository TodoRepository
+	todoRepository  TodoRepository
+	eventRepository TodoEventRepository
+	listRepository  ListRepository
+	userRepository  UserRepository
+	webhookOutbox   WebhookOutbox
+	notifier        Notifier
+	publisher       Publisher
+	transactor      Transactor
 }
 
-func NewTodoService(td TodoRepository) *TodoService {
+func NewTodoService(td TodoRepository, er TodoEventRepository, lr ListRepository, ur UserRepository, wo WebhookOutbox, n Notifier, pub Publisher, tx Transactor) *TodoService {
 	return &TodoService{
-		todoRepository: td,
+		todoRepository:  td,
+		eventRepository: er,
+		listRepository:  lr,
+		userRepository:  ur,
+		webhookOutbox:   wo,
+		notifier:        n,
+		publisher:       pub,
+		transactor:      tx,
 	}
 }
 
+// Fetch lists every todo. With no user to see them through, it lists no
+// dependencies.
 func (t *TodoService) Fetch(ctx context.Context, page int64, limit int64) (res []domain.Todo, err error) {
 	offset := (page - 1) * limit
 
@@ -35,27 +106,87 @@ func (t *TodoService) Fetch(ctx context.Context, page int64, limit int64) (res [
 		return nil, err
 	}
 
-	return
+	return res, t.withDetails(ctx, 0, res)
 }
 
-func (t *TodoService) GetByID(ctx context.Context, id int64) (res domain.Todo, err error) {
-	res, err = t.todoRepository.GetByID(ctx, id)
+func (t *TodoService) GetByID(ctx context.Context, userID int64, id int64) (res domain.Todo, err error) {
+	res, err = t.Authorize(ctx, userID, id, domain.RoleViewer)
 	if err != nil {
 		return
 	}
 
+	err = t.withDetail(ctx, userID, &res)
 	return
 }
 
-func (t *TodoService) GetByUserID(ctx context.Context, userID int64, page int64, limit int64, category *string, priorityLevel *string, keyword *string) (res []domain.Todo, err error) {
-    offset := (page - 1) * limit
+// GetByUserID lists the todos created by the user or, when filter.ListID is
+// set, every todo on that shared list.
+func (t *TodoService) GetByUserID(ctx context.Context, userID int64, page int64, limit int64, filter domain.TodoFilter) (res []domain.Todo, err error) {
+	offset := (page - 1) * limit
 
-    res, err = t.todoRepository.GetByUserID(ctx, userID, limit, offset, category, priorityLevel, keyword)
-    if err != nil {
-        return nil, err
-    }
+	if filter.ListID != nil {
+		if err = t.authorizeList(ctx, userID, *filter.ListID, domain.RoleViewer); err != nil {
+			return nil, err
+		}
+	}
 
-    return
+	res, err = t.todoRepository.GetByUserID(ctx, userID, limit, offset, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, t.withDetails(ctx, userID, res)
+}
+
+// Export calls fn for every todo matching the filter, without holding them
+// all in memory. Todos are passed on in chunks of exportChunkSize so their
+// details can be loaded together.
+func (t *TodoService) Export(ctx context.Context, userID int64, filter domain.TodoFilter, fn func(domain.Todo) error) (err error) {
+	if filter.ListID != nil {
+		if err = t.authorizeList(ctx, userID, *filter.ListID, domain.RoleViewer); err != nil {
+			return
+		}
+	}
+
+	chunk := make([]domain.Todo, 0, exportChunkSize)
+	flush := func() error {
+		if err := t.withDetails(ctx, userID, chunk); err != nil {
+			return err
+		}
+		for _, td := range chunk {
+			if err := fn(td); err != nil {
+				return err
+			}
+		}
+
+		chunk = chunk[:0]
+		return nil
+	}
+
+	err = t.todoRepository.StreamByUserID(ctx, userID, filter, func(td domain.Todo) error {
+		chunk = append(chunk, td)
+		if len(chunk) < exportChunkSize {
+			return nil
+		}
+
+		return flush()
+	})
+	if err != nil {
+		return
+	}
+
+	return flush()
+}
+
+func (t *TodoService) GetTrash(ctx context.Context, userID int64, page int64, limit int64) (res []domain.Todo, err error) {
+	offset := (page - 1) * limit
+
+	res, err = t.todoRepository.GetTrashByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, t.withDetails(ctx, userID, res)
 }
 
 func (t *TodoService) GetAllCategories(ctx context.Context) (res []string, err error) {
@@ -63,32 +194,492 @@ func (t *TodoService) GetAllCategories(ctx context.Context) (res []string, err e
 }
 
 func (t *TodoService) Store(ctx context.Context, td *domain.Todo) (err error) {
+	if td.ListID != nil {
+		if err = t.authorizeList(ctx, td.UserID, *td.ListID, domain.RoleEditor); err != nil {
+			return
+		}
+	}
+	if err = t.checkAssignee(ctx, *td); err != nil {
+		return
+	}
+
+	normalizeDate(td)
+	td.TrackedSeconds = 0
+	td.BlockedBy = []int64{}
+	td.Blocking = []int64{}
 	td.CreatedAt = time.Now()
 	td.UpdatedAt = time.Now()
-	return t.todoRepository.Store(ctx, td)
+
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := t.todoRepository.Store(ctx, td); err != nil {
+			return err
+		}
+		if err := t.record(ctx, td.UserID, td.ID, domain.TodoCreated, nil); err != nil {
+			return err
+		}
+
+		return t.enqueueWebhook(ctx, domain.WebhookTodoCreated, *td)
+	})
+	if err != nil {
+		return
+	}
+
+	t.publish(ctx, td.UserID, domain.TodoCreated, *td, nil)
+	return
 }
 
-func (t *TodoService) Update(ctx context.Context, td *domain.Todo) (err error) {
-	existedTodo, err := t.todoRepository.GetByID(ctx, td.ID)
+// StoreAll creates todos for userID in one transaction, recording and
+// announcing each like Store. prepare runs first in that transaction and
+// returns the list to file the todos in, or nil for personal todos, so that a
+// list it creates is rolled back along with them.
+func (t *TodoService) StoreAll(ctx context.Context, userID int64, todos []domain.Todo, prepare func(ctx context.Context) (*int64, error)) (err error) {
+	now := time.Now()
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		listID, err := prepare(ctx)
+		if err != nil {
+			return err
+		}
+		if listID != nil {
+			if err = t.authorizeList(ctx, userID, *listID, domain.RoleEditor); err != nil {
+				return err
+			}
+		}
+
+		for i := range todos {
+			td := &todos[i]
+			td.UserID = userID
+			td.ListID = listID
+			td.AssigneeID = nil
+			normalizeDate(td)
+			td.TrackedSeconds = 0
+			td.BlockedBy = []int64{}
+			td.Blocking = []int64{}
+			td.CreatedAt = now
+			td.UpdatedAt = now
+
+			if err = t.todoRepository.Store(ctx, td); err != nil {
+				return err
+			}
+			if err = t.record(ctx, userID, td.ID, domain.TodoCreated, nil); err != nil {
+				return err
+			}
+			if err = t.enqueueWebhook(ctx, domain.WebhookTodoCreated, *td); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
 		return
 	}
-	if existedTodo == (domain.Todo{}) {
-		return domain.ErrNotFound
+
+	for _, td := range todos {
+		t.publish(ctx, userID, domain.TodoCreated, td, nil)
 	}
+	return
+}
 
-	td.UpdatedAt = time.Now()
-	return t.todoRepository.Update(ctx, td)
+// Import adds validated todos in bulk, all in one transaction, and returns
+// how many were stored. With dryRun set only the access to the target list is
+// checked. Like todos created one by one, every imported todo is recorded in
+// its history and announced.
+func (t *TodoService) Import(ctx context.Context, userID int64, listID *int64, todos []domain.Todo, dryRun bool) (res int64, err error) {
+	if listID != nil {
+		if err = t.authorizeList(ctx, userID, *listID, domain.RoleEditor); err != nil {
+			return
+		}
+	}
+	if dryRun || len(todos) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	for i := range todos {
+		normalizeDate(&todos[i])
+		todos[i].UserID = userID
+		todos[i].ListID = listID
+		todos[i].AssigneeID = nil
+		todos[i].BlockedBy = []int64{}
+		todos[i].Blocking = []int64{}
+		todos[i].CreatedAt = now
+		todos[i].UpdatedAt = now
+		if !todos[i].Completed {
+			todos[i].CompletedAt = nil
+		} else if todos[i].CompletedAt == nil {
+			todos[i].CompletedAt = &now
+		}
+	}
+
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		res, err = t.todoRepository.StoreMany(ctx, userID, todos)
+		if err != nil {
+			return err
+		}
+
+		for _, td := range todos {
+			if err = t.record(ctx, userID, td.ID, domain.TodoCreated, nil); err != nil {
+				return err
+			}
+			if err = t.enqueueWebhook(ctx, domain.WebhookTodoCreated, td); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, td := range todos {
+		t.publish(ctx, userID, domain.TodoCreated, td, nil)
+	}
+	return
+}
+
+// Update replaces the todo on behalf of userID and records the changed
+// fields in its history. The creator and assignee are kept, and so is the
+// list unless list_id was sent. Moving the todo onto another list needs
+// editor access on that list too. An assignee
+// who cannot see the todo on its new list is dropped.
+func (t *TodoService) Update(ctx context.Context, userID int64, td *domain.Todo) (err error) {
+	var existedTodo domain.Todo
+	var unblocked []domain.Todo
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		existedTodo, err = t.lock(ctx, userID, td.ID, domain.RoleEditor)
+		if err != nil {
+			return err
+		}
+		if err = t.withDetail(ctx, userID, &existedTodo); err != nil {
+			return err
+		}
+		if !td.ListIDSet {
+			td.ListID = existedTodo.ListID
+		}
+		if td.ListID != nil && (existedTodo.ListID == nil || *td.ListID != *existedTodo.ListID) {
+			if err = t.authorizeList(ctx, userID, *td.ListID, domain.RoleEditor); err != nil {
+				return err
+			}
+		}
+
+		td.UserID = existedTodo.UserID
+		td.AssigneeID = existedTodo.AssigneeID
+		td.ArchivedAt = existedTodo.ArchivedAt
+		td.TrackedSeconds = existedTodo.TrackedSeconds
+		td.BlockedBy = existedTodo.BlockedBy
+		td.Blocking = existedTodo.Blocking
+		td.CreatedAt = existedTodo.CreatedAt
+		td.UpdatedAt = time.Now()
+		normalizeDate(td)
+		if err = t.keepAssignee(ctx, td); err != nil {
+			return err
+		}
+
+		if err = t.todoRepository.Update(ctx, td); err != nil {
+			return err
+		}
+		if err = t.recordUpdate(ctx, userID, existedTodo, *td); err != nil {
+			return err
+		}
+
+		unblocked, err = t.enqueueCompleted(ctx, userID, existedTodo, *td)
+		return err
+	})
+	if err != nil {
+		return
+	}
+
+	t.publish(ctx, userID, domain.TodoUpdated, *td, &existedTodo)
+	for _, u := range unblocked {
+		t.publish(ctx, userID, domain.TodoUnblocked, u, nil)
+	}
+	return
 }
 
-func (t *TodoService) Delete(ctx context.Context, id int64) (err error) {
+// normalizeDate stores the day of an all-day todo as midnight UTC, keeping
+// the calendar day the client sent whatever offset it was sent with. It is
+// read back in the user's zone.
+func normalizeDate(td *domain.Todo) {
+	if !td.AllDay || td.Date == nil {
+		return
+	}
+
+	y, m, d := td.Date.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	td.Date = &day
+}
+
+// keepAssignee clears the assignee of td when they no longer have access.
+func (t *TodoService) keepAssignee(ctx context.Context, td *domain.Todo) error {
+	err := t.checkAssignee(ctx, *td)
+	if err == domain.ErrAssigneeAccess {
+		td.AssigneeID = nil
+		return nil
+	}
+
+	return err
+}
+
+// Assign sets the assignee of the todo, or clears it when assigneeID is nil.
+// The assignee must be able to see the todo and is notified unless they
+// assigned it to themselves.
+func (t *TodoService) Assign(ctx context.Context, userID int64, id int64, assigneeID *int64) (res domain.Todo, err error) {
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		existedTodo, err := t.lock(ctx, userID, id, domain.RoleEditor)
+		if err != nil {
+			return err
+		}
+		if err = t.withDetail(ctx, userID, &existedTodo); err != nil {
+			return err
+		}
+
+		res = existedTodo
+		res.AssigneeID = assigneeID
+		if err = t.checkAssignee(ctx, res); err != nil {
+			return err
+		}
+
+		res.UpdatedAt = time.Now()
+		if err = t.todoRepository.SetAssignee(ctx, id, res.AssigneeID, res.UpdatedAt); err != nil {
+			return err
+		}
+		if err = t.recordUpdate(ctx, userID, existedTodo, res); err != nil {
+			return err
+		}
+
+		if assigneeID == nil || *assigneeID == userID || equalID(existedTodo.AssigneeID, assigneeID) {
+			return nil
+		}
+
+		return t.notifier.Notify(ctx, domain.Notification{
+			UserID:    *assigneeID,
+			Kind:      domain.NotificationAssignment,
+			Title:     "Assigned to you: " + res.Text,
+			Body:      fmt.Sprintf("You were assigned to %q.", res.Text),
+			TodoID:    &res.ID,
+			CreatedAt: res.UpdatedAt,
+		})
+	})
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	t.publish(ctx, userID, domain.TodoUpdated, res, nil)
+	return
+}
+
+// Delete moves the todo to the trash. When permanent is set the todo is
+// removed for good, whether it is still active or already in the trash.
+func (t *TodoService) Delete(ctx context.Context, userID int64, id int64, permanent bool) (err error) {
 	existedTodo, err := t.todoRepository.GetByID(ctx, id)
+	if err == domain.ErrNotFound && permanent {
+		existedTodo, err = t.todoRepository.GetTrashedByID(ctx, id)
+	}
+	if err != nil {
+		return
+	}
+	if err = t.authorize(ctx, userID, existedTodo, domain.RoleEditor); err != nil {
+		return
+	}
+
+	// The history goes together with a permanently deleted todo.
+	var unblocked []domain.Todo
+	if permanent {
+		err = t.todoRepository.HardDelete(ctx, id)
+	} else {
+		err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+			if err = t.todoRepository.Delete(ctx, id, time.Now()); err != nil {
+				return err
+			}
+			if err = t.record(ctx, userID, id, domain.TodoDeleted, nil); err != nil {
+				return err
+			}
+
+			unblocked, err = t.unblockDeleted(ctx, userID, existedTodo)
+			return err
+		})
+	}
 	if err != nil {
 		return
 	}
-	if existedTodo == (domain.Todo{}) {
-		return domain.ErrNotFound
+
+	t.publish(ctx, userID, domain.TodoDeleted, existedTodo, nil)
+	for _, u := range unblocked {
+		t.publish(ctx, userID, domain.TodoUnblocked, u, nil)
+	}
+	return
+}
+
+func (t *TodoService) Restore(ctx context.Context, userID int64, id int64) (res domain.Todo, err error) {
+	existedTodo, err := t.todoRepository.GetTrashedByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = t.authorize(ctx, userID, existedTodo, domain.RoleEditor); err != nil {
+		return
+	}
+
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := t.todoRepository.Restore(ctx, id, time.Now()); err != nil {
+			return err
+		}
+
+		return t.record(ctx, userID, id, domain.TodoRestored, nil)
+	})
+	if err != nil {
+		return
+	}
+
+	res, err = t.todoRepository.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = t.withDetail(ctx, userID, &res); err != nil {
+		return
+	}
+
+	t.publish(ctx, userID, domain.TodoRestored, res, nil)
+	return
+}
+
+// PurgeTrash hard-deletes todos that have been in the trash for longer than
+// the retention period.
+func (t *TodoService) PurgeTrash(ctx context.Context, retention time.Duration) (res int64, err error) {
+	return t.todoRepository.PurgeTrash(ctx, time.Now().Add(-retention))
+}
+
+func (t *TodoService) Archive(ctx context.Context, userID int64, id int64) (res domain.Todo, err error) {
+	now := time.Now()
+	return t.setArchived(ctx, userID, id, &now)
+}
+
+func (t *TodoService) Unarchive(ctx context.Context, userID int64, id int64) (res domain.Todo, err error) {
+	return t.setArchived(ctx, userID, id, nil)
+}
+
+func (t *TodoService) setArchived(ctx context.Context, userID int64, id int64, archivedAt *time.Time) (res domain.Todo, err error) {
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		existedTodo, err := t.lock(ctx, userID, id, domain.RoleEditor)
+		if err != nil {
+			return err
+		}
+		if err = t.withDetail(ctx, userID, &existedTodo); err != nil {
+			return err
+		}
+
+		res = existedTodo
+		res.ArchivedAt = archivedAt
+		res.UpdatedAt = time.Now()
+		if err = t.todoRepository.SetArchived(ctx, id, res.ArchivedAt, res.UpdatedAt); err != nil {
+			return err
+		}
+
+		return t.recordUpdate(ctx, userID, existedTodo, res)
+	})
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	t.publish(ctx, userID, domain.TodoUpdated, res, nil)
+	return
+}
+
+// ArchiveCompleted archives every completed todo of the user, recording each
+// in its history and announcing it, and returns how many were archived.
+func (t *TodoService) ArchiveCompleted(ctx context.Context, userID int64) (res int64, err error) {
+	now := time.Now()
+	var archived []domain.Todo
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		ids, err := t.todoRepository.ArchiveCompleted(ctx, userID, now)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err = t.recordArchived(ctx, userID, id, now); err != nil {
+				return err
+			}
+		}
+
+		archived, err = t.loadArchived(ctx, ids)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, td := range archived {
+		t.publish(ctx, userID, domain.TodoUpdated, td, nil)
+	}
+	return int64(len(archived)), nil
+}
+
+// AutoArchive runs the background auto-archive pass for all users that have
+// it enabled in their settings. The archiving is recorded in the history of
+// each todo and announced on behalf of its creator.
+func (t *TodoService) AutoArchive(ctx context.Context) (res int64, err error) {
+	now := time.Now()
+	var archived []domain.Todo
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		owners, err := t.todoRepository.AutoArchive(ctx, now)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]int64, 0, len(owners))
+		for id, userID := range owners {
+			if err = t.recordArchived(ctx, userID, id, now); err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+
+		archived, err = t.loadArchived(ctx, ids)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, td := range archived {
+		t.publish(ctx, td.UserID, domain.TodoUpdated, td, nil)
+	}
+	return int64(len(archived)), nil
+}
+
+// loadArchived loads the todos a bulk archive just archived, with the details
+// their creators see, so that they can be announced. Call it in the
+// transaction that archives them.
+func (t *TodoService) loadArchived(ctx context.Context, ids []int64) ([]domain.Todo, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	todos, err := t.todoRepository.GetByIDsForUpdate(ctx, ids)
+	if err != nil {
+		return nil, err
 	}
 
-	return t.todoRepository.Delete(ctx, id)
+	byUser := map[int64][]domain.Todo{}
+	for _, td := range todos {
+		byUser[td.UserID] = append(byUser[td.UserID], td)
+	}
+
+	res := make([]domain.Todo, 0, len(todos))
+	for userID, owned := range byUser {
+		if err = t.withDetails(ctx, userID, owned); err != nil {
+			return nil, err
+		}
+		res = append(res, owned...)
+	}
+
+	return res, nil
+}
+
+// recordArchived records the archiving of a todo the way setArchived does.
+func (t *TodoService) recordArchived(ctx context.Context, actorID int64, id int64, archivedAt time.Time) error {
+	return t.record(ctx, actorID, id, domain.TodoUpdated, map[string]domain.FieldChange{
+		"archived_at": {Old: (*time.Time)(nil), New: &archivedAt},
+	})
 }
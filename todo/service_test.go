@@ -0,0 +1,60 @@
+package todo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+func TestNormalizeDate(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name   string
+		date   time.Time
+		allDay bool
+		want   time.Time
+	}{
+		// Clients usually send an all-day date as local midnight, which is
+		// still the day before in UTC in zones ahead of it.
+		{"new york midnight before spring forward", time.Date(2024, time.March, 10, 0, 0, 0, 0, newYork), true, time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)},
+		{"new york late on spring forward day", time.Date(2024, time.March, 10, 23, 30, 0, 0, newYork), true, time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)},
+		{"new york midnight after spring forward", time.Date(2024, time.March, 11, 0, 0, 0, 0, newYork), true, time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC)},
+		{"new york midnight on fall back day", time.Date(2024, time.November, 3, 0, 0, 0, 0, newYork), true, time.Date(2024, time.November, 3, 0, 0, 0, 0, time.UTC)},
+		{"new york late on fall back day", time.Date(2024, time.November, 3, 23, 30, 0, 0, newYork), true, time.Date(2024, time.November, 3, 0, 0, 0, 0, time.UTC)},
+		{"sydney midnight on daylight saving end", time.Date(2024, time.April, 7, 0, 0, 0, 0, sydney), true, time.Date(2024, time.April, 7, 0, 0, 0, 0, time.UTC)},
+		{"sydney midnight on daylight saving start", time.Date(2024, time.October, 6, 0, 0, 0, 0, sydney), true, time.Date(2024, time.October, 6, 0, 0, 0, 0, time.UTC)},
+		{"sydney midnight on new year", time.Date(2025, time.January, 1, 0, 0, 0, 0, sydney), true, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"utc", time.Date(2024, time.March, 10, 15, 0, 0, 0, time.UTC), true, time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)},
+		{"timed todo is kept", time.Date(2024, time.March, 10, 3, 30, 0, 0, newYork), false, time.Date(2024, time.March, 10, 3, 30, 0, 0, newYork)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date := tt.date
+			td := domain.Todo{Date: &date, AllDay: tt.allDay}
+			normalizeDate(&td)
+
+			if !td.Date.Equal(tt.want) || td.Date.Location() != tt.want.Location() {
+				t.Errorf("normalizeDate(%v) = %v, want %v", tt.date, td.Date, tt.want)
+			}
+		})
+	}
+
+	t.Run("no date", func(t *testing.T) {
+		td := domain.Todo{AllDay: true}
+		normalizeDate(&td)
+
+		if td.Date != nil {
+			t.Errorf("normalizeDate(nil) = %v, want nil", td.Date)
+		}
+	})
+}
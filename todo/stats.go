@@ -0,0 +1,47 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+const (
+	// statsDays is the number of days up to today covered when no range is
+	// given.
+	statsDays = 30
+	// maxStatsDays caps the range of a stats request.
+	maxStatsDays = 366
+)
+
+// Stats summarises the user's todos. from and to are dates such as
+// 2024-10-01 in the user's time zone; the range defaults to the last
+// statsDays days.
+func (t *TodoService) Stats(ctx context.Context, userID int64, from string, to string) (res domain.Stats, err error) {
+	settings, err := t.userRepository.GetSettings(ctx, userID)
+	if err != nil {
+		return domain.Stats{}, err
+	}
+
+	loc, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		return domain.Stats{}, err
+	}
+
+	start, end, err := domain.ParseDateRange(from, to, loc, statsDays, maxStatsDays)
+	if err != nil {
+		return domain.Stats{}, err
+	}
+
+	res, err = t.todoRepository.GetStats(ctx, userID, settings.Timezone, start, end)
+	if err != nil {
+		return domain.Stats{}, err
+	}
+
+	res.Timezone = settings.Timezone
+	res.From = start.Format("2006-01-02")
+	res.To = end.Format("2006-01-02")
+
+	return
+}
@@ -0,0 +1,71 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// publish notifies everyone who can see td, and everyone who could see it
+// before a change when previous is given, that td changed. It is called after
+// the change has been committed; a failure is logged rather than returned as
+// the change itself succeeded.
+func (t *TodoService) publish(ctx context.Context, actorID int64, eventType domain.TodoEventType, td domain.Todo, previous *domain.Todo) {
+	userIDs, err := t.audience(ctx, td)
+	if err == nil && previous != nil && !equalID(previous.ListID, td.ListID) {
+		var previousIDs []int64
+		previousIDs, err = t.audience(ctx, *previous)
+		userIDs = append(userIDs, previousIDs...)
+	}
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+
+	ev := domain.StreamEvent{
+		Type:      eventType,
+		TodoID:    td.ID,
+		ActorID:   actorID,
+		CreatedAt: time.Now(),
+	}
+	if eventType != domain.TodoDeleted {
+		ev.Todo = &td
+	}
+
+	if err = t.publisher.Publish(ctx, uniqueIDs(userIDs), ev); err != nil {
+		logrus.Error(err)
+	}
+}
+
+// audience returns the users who can see td.
+func (t *TodoService) audience(ctx context.Context, td domain.Todo) (res []int64, err error) {
+	if td.ListID == nil {
+		return []int64{td.UserID}, nil
+	}
+
+	members, err := t.listRepository.GetMembers(ctx, *td.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range members {
+		res = append(res, m.UserID)
+	}
+
+	return
+}
+
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	res := ids[:0]
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+
+	return res
+}
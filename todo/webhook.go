@@ -0,0 +1,75 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+// overdueBatchSize caps how many overdue todos one pass reports, keeping the
+// transaction that locks them short.
+const overdueBatchSize = 100
+
+// enqueueWebhook queues event for the webhooks of everyone who can see td.
+// Call it in the transaction that makes the change.
+func (t *TodoService) enqueueWebhook(ctx context.Context, event domain.WebhookEvent, td domain.Todo) error {
+	userIDs, err := t.audience(ctx, td)
+	if err != nil {
+		return err
+	}
+
+	return t.webhookOutbox.Enqueue(ctx, userIDs, domain.WebhookPayload{
+		Event:     event,
+		CreatedAt: time.Now(),
+		Todo:      td,
+	})
+}
+
+// enqueueCompleted queues the completed event when an update completes td,
+// along with the unblocked events of the todos it was the last blocker of,
+// and returns those todos.
+func (t *TodoService) enqueueCompleted(ctx context.Context, actorID int64, old domain.Todo, td domain.Todo) ([]domain.Todo, error) {
+	if old.Completed || !td.Completed {
+		return nil, nil
+	}
+
+	if err := t.enqueueWebhook(ctx, domain.WebhookTodoCompleted, td); err != nil {
+		return nil, err
+	}
+
+	return t.unblock(ctx, actorID, td)
+}
+
+// NotifyOverdue queues the overdue event for open todos whose due date has
+// passed, once per due date, and returns how many todos it reported. Rows are
+// locked with SKIP LOCKED so several instances can run it side by side.
+func (t *TodoService) NotifyOverdue(ctx context.Context) (res int64, err error) {
+	now := time.Now()
+
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		overdue, err := t.todoRepository.LockOverdue(ctx, now, overdueBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(overdue) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(overdue))
+		for _, td := range overdue {
+			if err = t.enqueueWebhook(ctx, domain.WebhookTodoOverdue, td); err != nil {
+				return err
+			}
+			ids = append(ids, td.ID)
+		}
+
+		res = int64(len(ids))
+		return t.todoRepository.SetOverdueNotified(ctx, ids, now)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return
+}
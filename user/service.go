@@ -15,6 +15,8 @@ type UserRepository interface {
 	Register(ctx context.Context, user domain.User) error
 	GetByUsername(ctx context.Context, email string) (res domain.User, err error)
 	GetByID(ctx context.Context, id int64) (res domain.User, err error)
+	GetSettings(ctx context.Context, id int64) (domain.UserSettings, error)
+	UpdateSettings(ctx context.Context, id int64, settings domain.UserSettings, updatedAt time.Time) error
 }
 
 type UserService struct {
@@ -83,3 +85,15 @@ func (u *UserService) Register(ctx context.Context, user *domain.User) (token st
 
 	return token, nil
 }
+
+func (u *UserService) GetSettings(ctx context.Context, userID int64) (res domain.UserSettings, err error) {
+	return u.userRepository.GetSettings(ctx, userID)
+}
+
+func (u *UserService) UpdateSettings(ctx context.Context, userID int64, settings *domain.UserSettings) (err error) {
+	if settings.Timezone == "" {
+		settings.Timezone = "UTC"
+	}
+
+	return u.userRepository.UpdateSettings(ctx, userID, *settings, time.Now())
+}
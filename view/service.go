@@ -0,0 +1,98 @@
+package view
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type ViewRepository interface {
+	GetByUserID(ctx context.Context, userID int64) ([]domain.SavedView, error)
+	GetByID(ctx context.Context, id int64) (domain.SavedView, error)
+	Store(ctx context.Context, sv *domain.SavedView) error
+	Update(ctx context.Context, sv *domain.SavedView) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type ViewService struct {
+	viewRepository ViewRepository
+}
+
+func NewViewService(vr ViewRepository) *ViewService {
+	return &ViewService{
+		viewRepository: vr,
+	}
+}
+
+func (v *ViewService) Fetch(ctx context.Context, userID int64) (res []domain.SavedView, err error) {
+	return v.viewRepository.GetByUserID(ctx, userID)
+}
+
+func (v *ViewService) SmartViews(ctx context.Context) []domain.SmartView {
+	return domain.SmartViews
+}
+
+func (v *ViewService) GetByID(ctx context.Context, userID int64, id int64) (res domain.SavedView, err error) {
+	res, err = v.viewRepository.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if res.UserID != userID {
+		return domain.SavedView{}, domain.ErrNotFound
+	}
+
+	return
+}
+
+// Filter returns the filter of the smart view with the given key, or else of
+// the user's saved view with the given id.
+func (v *ViewService) Filter(ctx context.Context, userID int64, view string) (res domain.TodoFilter, err error) {
+	for _, sv := range domain.SmartViews {
+		if sv.Key == view {
+			return sv.Filter, nil
+		}
+	}
+
+	id, err := strconv.ParseInt(view, 10, 64)
+	if err != nil {
+		return domain.TodoFilter{}, domain.ErrNotFound
+	}
+
+	sv, err := v.GetByID(ctx, userID, id)
+	if err != nil {
+		return domain.TodoFilter{}, err
+	}
+
+	return sv.Filter, nil
+}
+
+func (v *ViewService) Store(ctx context.Context, userID int64, sv *domain.SavedView) (err error) {
+	sv.UserID = userID
+	sv.CreatedAt = time.Now()
+	sv.UpdatedAt = sv.CreatedAt
+
+	return v.viewRepository.Store(ctx, sv)
+}
+
+func (v *ViewService) Update(ctx context.Context, userID int64, sv *domain.SavedView) (err error) {
+	existedView, err := v.GetByID(ctx, userID, sv.ID)
+	if err != nil {
+		return
+	}
+
+	sv.UserID = existedView.UserID
+	sv.CreatedAt = existedView.CreatedAt
+	sv.UpdatedAt = time.Now()
+
+	return v.viewRepository.Update(ctx, sv)
+}
+
+func (v *ViewService) Delete(ctx context.Context, userID int64, id int64) (err error) {
+	if _, err = v.GetByID(ctx, userID, id); err != nil {
+		return
+	}
+
+	return v.viewRepository.Delete(ctx, id)
+}
@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+var errBlockedAddress = errors.New("webhook address is not public")
+
+// publicIP reports whether ip may receive webhooks. Loopback, link-local
+// (which covers cloud metadata endpoints), private and unspecified addresses
+// are refused so that webhooks cannot reach services inside the network.
+func publicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsPrivate() && !ip.IsUnspecified()
+}
+
+// checkURL resolves the host of a webhook URL and fails with
+// ErrWebhookAddress unless every address it resolves to is public.
+func checkURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return domain.ErrBadParamInput
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return domain.ErrWebhookAddress
+	}
+	for _, addr := range addrs {
+		if !publicIP(addr.IP) {
+			return domain.ErrWebhookAddress
+		}
+	}
+
+	return nil
+}
+
+// newClient returns the client deliveries are sent with. Its dialer checks
+// the address actually connected to, as a host may resolve differently by
+// the time a message is sent, and redirects are dialed the same way. Proxies
+// are not used, since they would hide the address from that check.
+func newClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network string, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || !publicIP(ip) {
+				return errBlockedAddress
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
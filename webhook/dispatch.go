@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+const (
+	// dispatchBatchSize is how many messages one pass claims. They are sent
+	// one after another.
+	dispatchBatchSize = 20
+	// leaseMargin is added to the time the batch may take to send when
+	// claiming it.
+	leaseMargin = time.Minute
+	// maxAttempts is how often a message is tried before it is given up on.
+	maxAttempts = 10
+	retryBase   = 30 * time.Second
+	retryMax    = 6 * time.Hour
+)
+
+// Dispatch sends the messages in the outbox that are due and returns how
+// many were delivered. Failed attempts are retried with exponential backoff.
+// Messages are claimed with a lease long enough to send the whole batch, so
+// several instances can dispatch side by side without sending a message
+// twice, and are sent outside of any transaction.
+func (w *WebhookService) Dispatch(ctx context.Context) (res int64, err error) {
+	now := time.Now()
+	lease := dispatchBatchSize*w.client.Timeout + leaseMargin
+
+	messages, err := w.webhookRepository.ClaimDue(ctx, now, now.Add(lease), dispatchBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, msg := range messages {
+		delivery := w.deliver(ctx, msg)
+		if err = w.complete(ctx, msg, delivery); err != nil {
+			return res, err
+		}
+		if delivery.Error == "" {
+			res++
+		}
+	}
+
+	return
+}
+
+// complete logs the delivery attempt and settles the message: delivered,
+// given up on or scheduled for another try.
+func (w *WebhookService) complete(ctx context.Context, msg domain.WebhookMessage, delivery domain.WebhookDelivery) error {
+	return w.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := w.webhookRepository.StoreDelivery(ctx, &delivery); err != nil {
+			return err
+		}
+
+		switch {
+		case delivery.Error == "":
+			return w.webhookRepository.MarkDelivered(ctx, msg.ID, delivery.Attempt, delivery.CreatedAt)
+		case delivery.Attempt >= maxAttempts:
+			return w.webhookRepository.MarkFailed(ctx, msg.ID, delivery.Attempt, delivery.CreatedAt)
+		default:
+			return w.webhookRepository.ScheduleRetry(ctx, msg.ID, delivery.Attempt, delivery.CreatedAt.Add(backoff(delivery.Attempt)))
+		}
+	})
+}
+
+// deliver posts the message to its endpoint. Any response outside 2xx
+// counts as a failure.
+func (w *WebhookService) deliver(ctx context.Context, msg domain.WebhookMessage) (res domain.WebhookDelivery) {
+	res = domain.WebhookDelivery{
+		MessageID: msg.ID,
+		WebhookID: msg.WebhookID,
+		Event:     msg.Event,
+		Attempt:   msg.Attempts + 1,
+	}
+
+	start := time.Now()
+	defer func() {
+		res.CreatedAt = time.Now()
+		res.DurationMs = res.CreatedAt.Sub(start).Milliseconds()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.URL, bytes.NewReader(msg.Payload))
+	if err != nil {
+		res.Error = err.Error()
+		return
+	}
+
+	timestamp := start.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", string(msg.Event))
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(msg.ID, 10))
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Webhook-Signature", "sha256="+Sign(msg.Secret, timestamp, msg.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		res.Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	res.StatusCode = &resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		res.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+
+	return
+}
+
+// backoff returns how long to wait before the next attempt after the given
+// number of failed attempts: 30s, 1m, 2m, ... up to 6h.
+func backoff(attempts int) time.Duration {
+	delay := retryBase << (attempts - 1)
+	if delay > retryMax || delay <= 0 {
+		return retryMax
+	}
+
+	return delay
+}
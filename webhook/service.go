@@ -0,0 +1,176 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/abrahammegantoro/to-do-list-be/domain"
+)
+
+type WebhookRepository interface {
+	GetByUserID(ctx context.Context, userID int64) ([]domain.Webhook, error)
+	GetByID(ctx context.Context, id int64) (domain.Webhook, error)
+	Store(ctx context.Context, wh *domain.Webhook) error
+	Update(ctx context.Context, wh *domain.Webhook) error
+	Delete(ctx context.Context, id int64) error
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int64) ([]domain.WebhookMessage, error)
+	MarkDelivered(ctx context.Context, id int64, attempts int, deliveredAt time.Time) error
+	ScheduleRetry(ctx context.Context, id int64, attempts int, nextAttemptAt time.Time) error
+	MarkFailed(ctx context.Context, id int64, attempts int, failedAt time.Time) error
+	Requeue(ctx context.Context, messageID int64, now time.Time) (int64, error)
+	StoreDelivery(ctx context.Context, d *domain.WebhookDelivery) error
+	GetDeliveries(ctx context.Context, webhookID int64, cursor string, num int64) ([]domain.WebhookDelivery, string, error)
+	GetDeliveryByID(ctx context.Context, id int64) (domain.WebhookDelivery, error)
+}
+
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type WebhookService struct {
+	webhookRepository WebhookRepository
+	transactor        Transactor
+	client            *http.Client
+}
+
+func NewWebhookService(wr WebhookRepository, tx Transactor, timeout time.Duration) *WebhookService {
+	return &WebhookService{
+		webhookRepository: wr,
+		transactor:        tx,
+		client:            newClient(timeout),
+	}
+}
+
+func (w *WebhookService) Fetch(ctx context.Context, userID int64) (res []domain.Webhook, err error) {
+	res, err = w.webhookRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range res {
+		res[i].Secret = ""
+	}
+
+	return
+}
+
+func (w *WebhookService) GetByID(ctx context.Context, userID int64, id int64) (res domain.Webhook, err error) {
+	res, err = w.getWebhook(ctx, userID, id)
+	if err != nil {
+		return
+	}
+
+	res.Secret = ""
+	return
+}
+
+func (w *WebhookService) getWebhook(ctx context.Context, userID int64, id int64) (res domain.Webhook, err error) {
+	res, err = w.webhookRepository.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if res.UserID != userID {
+		return domain.Webhook{}, domain.ErrNotFound
+	}
+
+	return
+}
+
+// Store registers a webhook, active unless told otherwise. A secret is
+// generated when none is given; the response is the only place it is shown.
+func (w *WebhookService) Store(ctx context.Context, userID int64, wh *domain.Webhook) (err error) {
+	if err = checkURL(ctx, wh.URL); err != nil {
+		return
+	}
+	if wh.Secret == "" {
+		if wh.Secret, err = generateSecret(); err != nil {
+			return
+		}
+	}
+
+	if wh.Active == nil {
+		active := true
+		wh.Active = &active
+	}
+	wh.UserID = userID
+	wh.CreatedAt = time.Now()
+	wh.UpdatedAt = wh.CreatedAt
+
+	return w.webhookRepository.Store(ctx, wh)
+}
+
+// Update replaces the webhook's settings. The secret and whether the webhook
+// is active are kept unless given.
+func (w *WebhookService) Update(ctx context.Context, userID int64, wh *domain.Webhook) (err error) {
+	existedWebhook, err := w.getWebhook(ctx, userID, wh.ID)
+	if err != nil {
+		return
+	}
+	if err = checkURL(ctx, wh.URL); err != nil {
+		return
+	}
+
+	if wh.Secret == "" {
+		wh.Secret = existedWebhook.Secret
+	}
+	if wh.Active == nil {
+		wh.Active = existedWebhook.Active
+	}
+	wh.UserID = existedWebhook.UserID
+	wh.CreatedAt = existedWebhook.CreatedAt
+	wh.UpdatedAt = time.Now()
+
+	err = w.webhookRepository.Update(ctx, wh)
+	if err != nil {
+		return
+	}
+
+	wh.Secret = ""
+	return
+}
+
+func (w *WebhookService) Delete(ctx context.Context, userID int64, id int64) (err error) {
+	if _, err = w.getWebhook(ctx, userID, id); err != nil {
+		return
+	}
+
+	return w.webhookRepository.Delete(ctx, id)
+}
+
+func (w *WebhookService) GetDeliveries(ctx context.Context, userID int64, id int64, cursor string, num int64) (res []domain.WebhookDelivery, nextCursor string, err error) {
+	if _, err = w.getWebhook(ctx, userID, id); err != nil {
+		return
+	}
+
+	return w.webhookRepository.GetDeliveries(ctx, id, cursor, num)
+}
+
+// Replay queues the message of a logged delivery to be sent again, whether
+// it was delivered or not, and returns the id of the new message.
+func (w *WebhookService) Replay(ctx context.Context, userID int64, id int64, deliveryID int64) (res int64, err error) {
+	if _, err = w.getWebhook(ctx, userID, id); err != nil {
+		return
+	}
+
+	delivery, err := w.webhookRepository.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return
+	}
+	if delivery.WebhookID != id {
+		return 0, domain.ErrNotFound
+	}
+
+	return w.webhookRepository.Requeue(ctx, delivery.MessageID, time.Now())
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}